@@ -0,0 +1,5 @@
+data "bowtie_current_user" "terraform" {}
+
+output "terraform_can_manage_policies" {
+  value = data.bowtie_current_user.terraform.authz_policies
+}
@@ -0,0 +1,6 @@
+ephemeral "bowtie_session" "api" {}
+
+data "http" "controllers" {
+  url             = "${ephemeral.bowtie_session.api.host}/-net/api/v0/organization/controller"
+  request_headers = ephemeral.bowtie_session.api.headers
+}
@@ -0,0 +1,10 @@
+resource "bowtie_collection" "internal" {
+  name = "Internal services"
+
+  members = [
+    for destination in ["10.0.0.0/8", "192.168.1.10", "*.corp.example.com"] : {
+      name     = destination
+      location = provider::bowtie::collection_member_location(destination)
+    }
+  ]
+}
@@ -0,0 +1,3 @@
+output "office_range" {
+  value = provider::bowtie::normalize_cidr("10.20.30.40/16") # "10.20.0.0/16"
+}
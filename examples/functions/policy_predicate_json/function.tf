@@ -0,0 +1,8 @@
+output "engineering_on_managed_devices" {
+  value = provider::bowtie::policy_predicate_json({
+    and = [
+      { user_group = bowtie_group.engineering.id },
+      { device_group = bowtie_device_group.managed.id },
+    ]
+  })
+}
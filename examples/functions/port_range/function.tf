@@ -0,0 +1,10 @@
+resource "bowtie_resource" "web" {
+  name     = "Web servers"
+  protocol = "tcp"
+  location = {
+    cidr = "10.0.10.0/24"
+  }
+  ports = {
+    range = provider::bowtie::port_range(8000, 8443)
+  }
+}
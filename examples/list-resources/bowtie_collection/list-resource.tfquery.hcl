@@ -0,0 +1,7 @@
+list "bowtie_collection" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "internal"
+  }
+}
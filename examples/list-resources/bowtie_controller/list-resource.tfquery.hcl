@@ -0,0 +1,7 @@
+list "bowtie_controller" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "east"
+  }
+}
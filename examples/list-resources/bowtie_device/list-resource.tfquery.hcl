@@ -0,0 +1,7 @@
+list "bowtie_device" "laptops" {
+  provider = bowtie
+
+  config {
+    name_contains = "macbook"
+  }
+}
@@ -0,0 +1,7 @@
+list "bowtie_device_group" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "laptops"
+  }
+}
@@ -0,0 +1,7 @@
+list "bowtie_device_group_membership" "existing" {
+  provider = bowtie
+
+  config {
+    name = "Corporate Laptops"
+  }
+}
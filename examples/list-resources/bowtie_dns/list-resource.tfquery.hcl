@@ -0,0 +1,7 @@
+list "bowtie_dns" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "example.com"
+  }
+}
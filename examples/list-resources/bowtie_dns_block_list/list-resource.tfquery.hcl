@@ -0,0 +1,7 @@
+list "bowtie_dns_block_list" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "malware"
+  }
+}
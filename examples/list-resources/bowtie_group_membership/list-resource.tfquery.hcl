@@ -0,0 +1,7 @@
+list "bowtie_group_membership" "existing" {
+  provider = bowtie
+
+  config {
+    name = "Administrators"
+  }
+}
@@ -0,0 +1,7 @@
+list "bowtie_ipv4_range" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "192.0.2"
+  }
+}
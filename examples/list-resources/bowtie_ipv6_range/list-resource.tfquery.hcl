@@ -0,0 +1,7 @@
+list "bowtie_ipv6_range" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "fd"
+  }
+}
@@ -0,0 +1,7 @@
+list "bowtie_policy" "existing" {
+  provider = bowtie
+
+  config {
+    name = "Engineering Resources"
+  }
+}
@@ -0,0 +1,7 @@
+list "bowtie_resource" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "git"
+  }
+}
@@ -0,0 +1,7 @@
+list "bowtie_resource_group" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "engineering"
+  }
+}
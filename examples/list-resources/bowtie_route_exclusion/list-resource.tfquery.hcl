@@ -0,0 +1,7 @@
+list "bowtie_route_exclusion" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "printer"
+  }
+}
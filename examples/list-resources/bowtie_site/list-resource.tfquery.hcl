@@ -0,0 +1,7 @@
+list "bowtie_site" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "office"
+  }
+}
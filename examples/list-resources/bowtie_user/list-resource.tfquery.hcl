@@ -0,0 +1,7 @@
+list "bowtie_user" "existing" {
+  provider = bowtie
+
+  config {
+    name_contains = "@example.com"
+  }
+}
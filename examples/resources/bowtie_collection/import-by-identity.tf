@@ -0,0 +1,6 @@
+import {
+  to = bowtie_collection.internal_endpoints
+  identity = {
+    name = "Internal Endpoints"
+  }
+}
@@ -19,3 +19,12 @@ resource "bowtie_collection" "internal_endpoints" {
     },
   ]
 }
+
+# Keep the IP ranges a vendor publishes in sync, for example to route-exclude
+# them, without copying them into configuration. The sources and lists on
+# offer depend on the Controller; the Controller manages the members.
+resource "bowtie_collection" "video_conferencing" {
+  name      = "Video conferencing ranges"
+  source    = "zoom"
+  source_id = "zoom-meetings"
+}
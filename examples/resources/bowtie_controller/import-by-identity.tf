@@ -0,0 +1,6 @@
+import {
+  to = bowtie_controller.east
+  identity = {
+    public_address = "controller-east.example.com"
+  }
+}
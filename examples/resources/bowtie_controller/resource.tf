@@ -14,6 +14,28 @@ resource "bowtie_controller" "east" {
   version_strategy_splay_value = "30m"
   version_minimum_age          = 7
 
+  # Write backups to an S3 bucket and to a local directory for disaster
+  # recovery. The Controller uses its host's credentials for the bucket.
+  backup_strategies = [
+    {
+      type   = "s3"
+      bucket = "example-bowtie-backups"
+      prefix = "controllers/east/"
+      region = "us-east-1"
+    },
+    {
+      type = "directory"
+      path = "/var/lib/bowtie/backups"
+    },
+  ]
+
+  # Let this Controller run device cleanup once 60% of its peers are
+  # connected, instead of the organization's quorum.
+  minimum_peers_behavior = {
+    type       = "percentage"
+    percentage = 60
+  }
+
   # Optional values that inherit organization defaults are preserved when
   # omitted. To clear a per-Controller override, list it here.
   clear_overrides = [
@@ -0,0 +1,6 @@
+import {
+  to = bowtie_device.jdoe_laptop
+  identity = {
+    serial = "C02XK0ABJG5J"
+  }
+}
@@ -0,0 +1 @@
+terraform import bowtie_device.jdoe_laptop 3f6c2a1e-9b4d-4e8a-a7c5-1d2e3f4a5b6c
@@ -0,0 +1,18 @@
+# Devices enroll themselves when the Bowtie client first connects. Adopt a
+# corporate laptop by the serial number from the asset inventory, approve it,
+# and assign it to its user.
+resource "bowtie_device" "jdoe_laptop" {
+  serial           = "C02XK0ABJG5J"
+  state            = "accepted"
+  assigned_to_user = bowtie_user.jdoe.id
+  owned_by_org     = true
+}
+
+# Retire a lost device: reject it, remove its assignment, and delete it once
+# the resource is removed from the configuration.
+resource "bowtie_device" "lost_phone" {
+  id                = "3f6c2a1e-9b4d-4e8a-a7c5-1d2e3f4a5b6c"
+  state             = "rejected"
+  assigned_to_user  = ""
+  delete_on_destroy = true
+}
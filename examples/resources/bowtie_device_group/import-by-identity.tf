@@ -0,0 +1,6 @@
+import {
+  to = bowtie_device_group.corporate_laptops
+  identity = {
+    name = "Corporate Laptops"
+  }
+}
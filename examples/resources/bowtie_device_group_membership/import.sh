@@ -0,0 +1 @@
+terraform import bowtie_device_group_membership.corporate_laptops 47480e17-e7a2-4f7d-a0c0-3db8fd86c4ff
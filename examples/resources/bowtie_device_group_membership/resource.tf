@@ -0,0 +1,17 @@
+resource "bowtie_device_group" "corporate_laptops" {
+  name = "Corporate Laptops"
+}
+
+# The group holds every organization-owned Mac, picked up as it enrolls, plus
+# a loaner laptop listed by ID.
+resource "bowtie_device_group_membership" "corporate_laptops" {
+  group_id = bowtie_device_group.corporate_laptops.id
+  devices = [
+    "3f6c2a1e-9b4d-4e8a-a7c5-1d2e3f4a5b6c",
+  ]
+
+  selector = {
+    device_os    = "macos"
+    owned_by_org = true
+  }
+}
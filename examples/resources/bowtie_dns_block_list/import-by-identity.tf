@@ -0,0 +1,6 @@
+import {
+  to = bowtie_dns_block_list.example
+  identity = {
+    id = "5468dd20-af6e-4d8e-9b53-dd255f04f560"
+  }
+}
@@ -7,3 +7,11 @@ resource "bowtie_dns_block_list" "example" {
     "permitted.example.com"
   ]
 }
+
+# Resolve only the names kiosks need, denying everything else:
+
+resource "bowtie_dns_block_list" "kiosk" {
+  name         = "Kiosk Allow List"
+  upstream     = "https://intranet.example.com/kiosk-domains.txt"
+  is_allowlist = true
+}
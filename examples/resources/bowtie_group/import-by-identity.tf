@@ -0,0 +1,6 @@
+import {
+  to = bowtie_group.admins
+  identity = {
+    name = "Administrators"
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = bowtie_group_membership.admins
+  identity = {
+    group_id = "47480e17-e7a2-4f7d-a0c0-3db8fd86c4ff"
+  }
+}
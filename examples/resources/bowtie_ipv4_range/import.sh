@@ -0,0 +1 @@
+terraform import bowtie_ipv4_range.lan 47480e17-e7a2-4f7d-a0c0-3db8fd86c4ff
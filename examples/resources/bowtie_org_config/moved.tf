@@ -0,0 +1,6 @@
+# Hand the organization over from a bowtie_organization resource, which can
+# be neither created nor destroyed, without removing and re-importing it.
+moved {
+  from = bowtie_organization.this
+  to   = bowtie_org_config.this
+}
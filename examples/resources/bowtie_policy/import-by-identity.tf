@@ -0,0 +1,6 @@
+import {
+  to = bowtie_policy.engineering_access
+  identity = {
+    id = "4357c170-1a51-495e-b172-81ea0b2d1e78"
+  }
+}
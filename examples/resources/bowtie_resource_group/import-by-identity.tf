@@ -0,0 +1,6 @@
+import {
+  to = bowtie_resource_group.example
+  identity = {
+    name = "Internal Address"
+  }
+}
@@ -0,0 +1,7 @@
+import {
+  to = bowtie_site_range.office
+  identity = {
+    site_id = "47480e17-e7a2-4f7d-a0c0-3db8fd86c4ff"
+    name    = "Office"
+  }
+}
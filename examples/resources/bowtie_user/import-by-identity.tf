@@ -0,0 +1,6 @@
+import {
+  to = bowtie_user.jane
+  identity = {
+    email = "jane.doe@example.com"
+  }
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -18,24 +19,47 @@ type User struct {
 	Email             string `json:"email"`
 	AuthZDevices      bool   `json:"authz_devices"`
 	AuthZPolicies     bool   `json:"authz_policies"`
-	AuthZControlPanel bool   `json:"authz_control_panel"`
+	AuthZControlPlane bool   `json:"authz_control_plane"`
 	AuthZUsers        bool   `json:"authz_users"`
 	Role              string `json:"role"`
 }
 
-func (c *Client) Login() error {
+// Login establishes a session cookie from the configured email and password,
+// with the first Controller endpoint that can be reached. Clients
+// authenticated with an API token have no session to establish, so Login does
+// nothing for them.
+func (c *Client) Login(ctx context.Context) error {
+	if c.apiToken != "" {
+		return nil
+	}
+
+	var err error
+	for _, endpoint := range c.endpointOrder() {
+		if err = c.login(ctx, endpoint); err == nil {
+			c.markHealthy(endpoint)
+			return nil
+		}
+		if !isDialError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// login establishes a session cookie with one Controller endpoint.
+func (c *Client) login(ctx context.Context, endpoint string) error {
 	payload, err := json.Marshal(c.auth)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", c.getHostURL("/user/login"), strings.NewReader(string(payload)))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint+apiVersionPrefix+"/user/login", strings.NewReader(string(payload)))
 	if err != nil {
 		return err
 	}
 	req.Header.Add("Content-Type", "application/json")
 
-	res, err := c.HTTPClient.Do(req)
+	res, _, err := c.send(req)
 	if err != nil {
 		return err
 	}
@@ -47,8 +71,10 @@ func (c *Client) Login() error {
 	return nil
 }
 
-func (c *Client) WhoAmI() (*Me, error) {
-	req, err := http.NewRequest("GET", c.getHostURL("/user/me"), nil)
+// WhoAmI returns the account the client is authenticated as, along with its
+// devices.
+func (c *Client) WhoAmI(ctx context.Context) (*Me, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.getHostURL("/user/me"), nil)
 	if err != nil {
 		return nil, err
 	}
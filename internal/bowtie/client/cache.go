@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultReadCacheTTL is how long a cached response stays fresh unless
+// WithReadCacheTTL says otherwise. It is long enough for one refresh of a large
+// state to share a single download and short enough that changes made outside
+// Terraform show up on the next plan.
+const DefaultReadCacheTTL = 30 * time.Second
+
+// cachedPaths are the GET endpoints whose responses are cached. Each returns a
+// whole collection that many resources read from: /policy carries every
+// policy, resource and resource group.
+var cachedPaths = []string{"/policy", "/organization"}
+
+// readCache coalesces concurrent identical GETs into a single request and keeps
+// the responses of cachedPaths for a short while. Any write made through the
+// client empties it.
+type readCache struct {
+	ttl     time.Duration
+	flights singleflight.Group
+
+	mu sync.Mutex
+	// generation counts the writes seen so far. A response is only stored
+	// if no write happened while it was being fetched, and only shared with
+	// requests issued in the same generation.
+	generation uint64
+	entries    map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// get answers a GET from the cache when it can and otherwise sends it through
+// fetch, sharing the outcome with identical GETs already in flight.
+func (rc *readCache) get(req *http.Request, cacheable bool, fetch func(*http.Request) ([]byte, error)) ([]byte, error) {
+	key := req.URL.String()
+
+	rc.mu.Lock()
+	generation := rc.generation
+	if entry, ok := rc.entries[key]; ok && cacheable && time.Now().Before(entry.expires) {
+		rc.mu.Unlock()
+		return entry.body, nil
+	}
+	rc.mu.Unlock()
+
+	flight := strconv.FormatUint(generation, 10) + " " + key
+	body, err, shared := rc.flights.Do(flight, func() (interface{}, error) {
+		body, err := fetch(req)
+		if err == nil && cacheable && rc.ttl > 0 {
+			rc.mu.Lock()
+			if rc.generation == generation {
+				rc.entries[key] = cacheEntry{body: body, expires: time.Now().Add(rc.ttl)}
+			}
+			rc.mu.Unlock()
+		}
+		return body, err
+	})
+
+	// The shared request ran with the context of whichever caller started
+	// it. When that caller gave up, the others still want an answer.
+	if shared && isContextError(err) && req.Context().Err() == nil {
+		return fetch(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return body.([]byte), nil
+}
+
+// invalidate drops every cached response and stops in-flight GETs from
+// storing theirs.
+func (rc *readCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	rc.entries = map[string]cacheEntry{}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
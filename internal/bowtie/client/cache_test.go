@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPolicyDocumentIsDownloadedOncePerWrite(t *testing.T) {
+	var downloads int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && r.URL.Path == "/-net/api/v0/policy" {
+			atomic.AddInt32(&downloads, 1)
+			_, _ = io.WriteString(w, `{"policies":{"p-1":{"id":"p-1"}},"resources":{"r-1":{"id":"r-1"}},"resource_groups":{}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.cache = newReadCache(time.Minute)
+	ctx := context.Background()
+
+	if _, err := c.GetPolicy(ctx, "p-1"); err != nil {
+		t.Fatalf("GetPolicy: %v", err)
+	}
+	if _, err := c.GetResources(ctx); err != nil {
+		t.Fatalf("GetResources: %v", err)
+	}
+	if _, err := c.GetResourceGroups(ctx); err != nil {
+		t.Fatalf("GetResourceGroups: %v", err)
+	}
+	if got := atomic.LoadInt32(&downloads); got != 1 {
+		t.Fatalf("downloads = %d, want reads to share one /policy download", got)
+	}
+
+	if err := c.DeletePolicy(ctx, "p-1"); err != nil {
+		t.Fatalf("DeletePolicy: %v", err)
+	}
+	if _, err := c.GetResources(ctx); err != nil {
+		t.Fatalf("GetResources: %v", err)
+	}
+	if got := atomic.LoadInt32(&downloads); got != 2 {
+		t.Errorf("downloads = %d, want a write to invalidate the cached document", got)
+	}
+}
+
+func TestConcurrentIdenticalGetsAreCoalesced(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	// Without a TTL nothing is cached, so only coalescing can save requests.
+	c.cache = newReadCache(0)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetGroups(context.Background())
+			errs <- err
+		}()
+	}
+	// Give every goroutine time to join the request in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetGroups: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestReadCacheDropsResponsesRacingAWrite(t *testing.T) {
+	rc := newReadCache(time.Minute)
+	req := httptest.NewRequest(http.MethodGet, "https://bowtie.example.com/-net/api/v0/policy", nil)
+
+	var fetches int
+	fetch := func(*http.Request) ([]byte, error) {
+		fetches++
+		if fetches == 1 {
+			// A write lands while the first read is in flight.
+			rc.invalidate()
+		}
+		return []byte(`{}`), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := rc.get(req, true, fetch); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	}
+	if fetches != 2 {
+		t.Errorf("fetches = %d, want the response fetched before the write to be discarded", fetches)
+	}
+}
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Capabilities describes what the organization's Controllers support, as
+// detected by Client.Capabilities.
+type Capabilities struct {
+	// Version is the oldest current_version among the organization's
+	// Controllers, since every Controller must understand what Terraform
+	// writes. It is empty when no Controller reports a version.
+	Version string
+	// controllerFields holds the members of the Controller representation
+	// served by every Controller; nil when it could not be determined.
+	controllerFields map[string]bool
+}
+
+// SupportsControllerField reports whether every Controller serves the named
+// member of its representation, and so accepts it on update. When the
+// representation could not be inspected every field is assumed supported.
+func (caps *Capabilities) SupportsControllerField(field string) bool {
+	if caps == nil || caps.controllerFields == nil {
+		return true
+	}
+	return caps.controllerFields[field]
+}
+
+// WithTaggedLocationDetection makes TaggedLocations follow the encoding the
+// Controller already uses for resource locations, instead of the
+// tagged_locations value passed to NewClient.
+func WithTaggedLocationDetection() Option {
+	return func(o *clientOptions) {
+		o.detectTaggedLocations = true
+	}
+}
+
+// TaggedLocations reports whether resource locations are sent in the tagged
+// encoding: as configured, or as detected with WithTaggedLocationDetection.
+// Should detection fail, the configured value stands. The detected encoding
+// is remembered, and so is a detection the account is forbidden to make; any
+// other failure is retried on the next call.
+func (c *Client) TaggedLocations(ctx context.Context) bool {
+	if !c.detectTaggedLocations {
+		return c.Tagged_locations
+	}
+
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+
+	if c.taggedLocations != nil {
+		return *c.taggedLocations
+	}
+
+	tagged, err := c.detectTaggedLocationEncoding(ctx)
+	if err != nil {
+		tflog.SubsystemWarn(c.logContext(ctx), logSubsystem, "Could not detect the resource location encoding", map[string]interface{}{
+			"error":            err.Error(),
+			"tagged_locations": c.Tagged_locations,
+		})
+		if !errors.Is(err, ErrForbidden) {
+			return c.Tagged_locations
+		}
+		tagged = c.Tagged_locations
+	} else {
+		tflog.SubsystemDebug(c.logContext(ctx), logSubsystem, "Detected the resource location encoding", map[string]interface{}{
+			"tagged_locations": tagged,
+		})
+	}
+	c.taggedLocations = &tagged
+	return tagged
+}
+
+// Capabilities detects the Controller version and feature set the first time
+// it is called and returns the same result afterwards. When the account may
+// not list Controllers the capabilities are remembered as unknown, so every
+// field is assumed supported; any other failure is retried on the next call.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+
+	if c.capabilities != nil {
+		return c.capabilities, nil
+	}
+
+	caps, err := c.detectCapabilities(ctx)
+	if err != nil {
+		tflog.SubsystemWarn(c.logContext(ctx), logSubsystem, "Could not detect Controller capabilities", map[string]interface{}{
+			"error": err.Error(),
+		})
+		if !errors.Is(err, ErrForbidden) {
+			return nil, err
+		}
+		caps = &Capabilities{}
+	} else {
+		tflog.SubsystemDebug(c.logContext(ctx), logSubsystem, "Detected Controller capabilities", map[string]interface{}{
+			"version": caps.Version,
+		})
+	}
+	c.capabilities = caps
+	return caps, nil
+}
+
+func (c *Client) detectCapabilities(ctx context.Context) (*Capabilities, error) {
+	var controllers []map[string]json.RawMessage
+	if err := c.getListJSON(ctx, &controllers, "/organization/controller", "/organization/controller/"); err != nil {
+		return nil, err
+	}
+
+	caps := &Capabilities{}
+	for i, controller := range controllers {
+		var version string
+		if raw, ok := controller["current_version"]; ok && json.Unmarshal(raw, &version) == nil && version != "" {
+			if caps.Version == "" || compareVersions(version, caps.Version) < 0 {
+				caps.Version = version
+			}
+		}
+
+		if i == 0 {
+			caps.controllerFields = map[string]bool{}
+			for field := range controller {
+				caps.controllerFields[field] = true
+			}
+			continue
+		}
+		for field := range caps.controllerFields {
+			if _, ok := controller[field]; !ok {
+				delete(caps.controllerFields, field)
+			}
+		}
+	}
+
+	return caps, nil
+}
+
+// detectTaggedLocationEncoding inspects the resources already on the
+// Controller. Any resource stored in the legacy untagged encoding means the
+// Controller predates tagged locations; without any resources the configured
+// encoding stands.
+func (c *Client) detectTaggedLocationEncoding(ctx context.Context) (bool, error) {
+	resources, err := c.GetResources(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	ids := make([]string, 0, len(resources))
+	for id := range resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		location := resources[id].Location
+		if location.Untagged != nil {
+			return false, nil
+		}
+		if location.Tagged != nil {
+			return true, nil
+		}
+	}
+	return c.Tagged_locations, nil
+}
+
+// compareVersions orders dotted Controller versions such as "24.06.002" by
+// their numeric components, returning -1, 0 or 1. Pre-release and build
+// suffixes are ignored.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
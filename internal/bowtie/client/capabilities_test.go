@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCapabilitiesDetectedOnce(t *testing.T) {
+	var listings int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/-net/api/v0/organization/controller":
+			atomic.AddInt32(&listings, 1)
+			_, _ = io.WriteString(w, `[
+				{"id":"c-1","current_version":"24.10.001","ssh_listener":null,"version_minimum_age":3},
+				{"id":"c-2","current_version":"24.6.003","version_minimum_age":null}
+			]`)
+		case "/-net/api/v0/policy":
+			_, _ = io.WriteString(w, `{"policies":{},"resource_groups":{},"resources":{
+				"r-1":{"id":"r-1","name":"legacy","protocol":"all","location":{"cidr":"10.0.0.0/8"},"ports":{"collection":{"ports":[]}}}
+			}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.Tagged_locations = true
+	c.detectTaggedLocations = true
+	ctx := context.Background()
+
+	caps, err := c.Capabilities(ctx)
+	if err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if caps.Version != "24.6.003" {
+		t.Errorf("Version = %q, want the oldest Controller's", caps.Version)
+	}
+	if !caps.SupportsControllerField("version_minimum_age") {
+		t.Error("version_minimum_age is served by every Controller")
+	}
+	if caps.SupportsControllerField("ssh_listener") {
+		t.Error("ssh_listener is missing from one Controller and must not be supported")
+	}
+	if c.TaggedLocations(ctx) {
+		t.Error("expected the legacy location encoding the Controller already stores")
+	}
+
+	if _, err := c.Capabilities(ctx); err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if got := atomic.LoadInt32(&listings); got != 1 {
+		t.Errorf("listings = %d, want capabilities detected once", got)
+	}
+}
+
+func TestCapabilitiesRemembersForbiddenDetection(t *testing.T) {
+	var listings, policies int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/-net/api/v0/organization/controller", "/-net/api/v0/organization/controller/":
+			// The account lacks authz_control_plane.
+			atomic.AddInt32(&listings, 1)
+			w.WriteHeader(http.StatusForbidden)
+		case "/-net/api/v0/user/login":
+			// The client takes the 403 for an expired session once.
+		case "/-net/api/v0/policy":
+			atomic.AddInt32(&policies, 1)
+			_, _ = io.WriteString(w, `{"policies":{},"resource_groups":{},"resources":{
+				"r-1":{"id":"r-1","name":"legacy","protocol":"all","location":{"cidr":"10.0.0.0/8"},"ports":{"collection":{"ports":[]}}}
+			}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.Tagged_locations = true
+	c.detectTaggedLocations = true
+	ctx := context.Background()
+
+	var listed int32
+	for i := 0; i < 3; i++ {
+		caps, err := c.Capabilities(ctx)
+		if err != nil {
+			t.Fatalf("Capabilities: %v", err)
+		}
+		if caps.Version != "" || !caps.SupportsControllerField("ssh_listener") {
+			t.Errorf("expected unknown capabilities, got %+v", caps)
+		}
+		if c.TaggedLocations(ctx) {
+			t.Error("expected the legacy location encoding despite the forbidden Controller listing")
+		}
+		if i == 0 {
+			listed = atomic.LoadInt32(&listings)
+		}
+	}
+
+	if got := atomic.LoadInt32(&listings); got != listed {
+		t.Errorf("listings = %d, want the forbidden listing remembered after %d", got, listed)
+	}
+	if got := atomic.LoadInt32(&policies); got != 1 {
+		t.Errorf("policy reads = %d, want the location encoding detected once", got)
+	}
+}
+
+func TestTaggedLocationsKeepsConfiguredValueWithoutDetection(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.Tagged_locations = true
+	if !c.TaggedLocations(context.Background()) {
+		t.Error("expected the configured tagged encoding")
+	}
+}
+
+func TestSupportsControllerFieldWhenUnknown(t *testing.T) {
+	var caps *Capabilities
+	if !caps.SupportsControllerField("ssh_listener") {
+		t.Error("fields must be assumed supported when capabilities are unknown")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"24.06.002", "24.6.2", 0},
+		{"24.06.002", "24.10.001", -1},
+		{"v25.1.0", "24.12.9", 1},
+		{"24.6", "24.6.1", -1},
+		{"24.6.1-rc1", "24.6.1", 0},
+	}
+	for _, tc := range cases {
+		if got := compareVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -37,9 +38,11 @@ type BowtieCollectionLocation struct {
 }
 
 type collectionUpsert struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Source      *string `json:"source,omitempty"`
+	SourceID    *string `json:"source_id,omitempty"`
 }
 
 type addCollectionMembers struct {
@@ -52,25 +55,30 @@ type removeCollectionMembers struct {
 	Members      []string `json:"members"`
 }
 
-func (c *Client) GetCollections() (map[string]BowtieCollection, error) {
+func (c *Client) GetCollections(ctx context.Context) (map[string]BowtieCollection, error) {
 	collections := map[string]BowtieCollection{}
-	if err := c.getListJSON(&collections, "/collection/?with-members=true", "/collection?with-members=true"); err != nil {
+	if err := c.getListJSON(ctx, &collections, "/collection/?with-members=true", "/collection?with-members=true"); err != nil {
 		return nil, err
 	}
 	return collections, nil
 }
 
-func (c *Client) UpsertCollection(id, name, description string) error {
+// UpsertCollection creates or updates a collection. A collection with a
+// source is synced from that upstream feed by the Controller, which then owns
+// its members; source and sourceID are nil for a collection of static members.
+func (c *Client) UpsertCollection(ctx context.Context, id, name, description string, source, sourceID *string) error {
 	payload, err := json.Marshal(collectionUpsert{
 		ID:          id,
 		Name:        name,
 		Description: description,
+		Source:      source,
+		SourceID:    sourceID,
 	})
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/collection/upsert"), bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/collection/upsert"), bytes.NewBuffer(payload))
 	if err != nil {
 		return err
 	}
@@ -79,7 +87,7 @@ func (c *Client) UpsertCollection(id, name, description string) error {
 	return err
 }
 
-func (c *Client) AddCollectionMembers(collectionID string, members []BowtieCollectionMember) error {
+func (c *Client) AddCollectionMembers(ctx context.Context, collectionID string, members []BowtieCollectionMember) error {
 	if len(members) == 0 {
 		return nil
 	}
@@ -92,7 +100,7 @@ func (c *Client) AddCollectionMembers(collectionID string, members []BowtieColle
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/collection/addmember"), bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/collection/addmember"), bytes.NewBuffer(payload))
 	if err != nil {
 		return err
 	}
@@ -101,7 +109,7 @@ func (c *Client) AddCollectionMembers(collectionID string, members []BowtieColle
 	return err
 }
 
-func (c *Client) RemoveCollectionMembers(collectionID string, memberIDs []string) error {
+func (c *Client) RemoveCollectionMembers(ctx context.Context, collectionID string, memberIDs []string) error {
 	if len(memberIDs) == 0 {
 		return nil
 	}
@@ -115,7 +123,7 @@ func (c *Client) RemoveCollectionMembers(collectionID string, memberIDs []string
 			return err
 		}
 
-		req, err := http.NewRequest(http.MethodPost, c.getHostURL("/collection/removemember"), bytes.NewBuffer(payload))
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/collection/removemember"), bytes.NewBuffer(payload))
 		if err != nil {
 			return err
 		}
@@ -127,8 +135,8 @@ func (c *Client) RemoveCollectionMembers(collectionID string, memberIDs []string
 	return nil
 }
 
-func (c *Client) DeleteCollection(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/collection/%s", id)), nil)
+func (c *Client) DeleteCollection(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/collection/%s", id)), nil)
 	if err != nil {
 		return err
 	}
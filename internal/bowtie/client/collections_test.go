@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -38,10 +39,43 @@ func TestRemoveCollectionMembersSendsOneRequestPerMember(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	if err := newTestClient(t, ts).RemoveCollectionMembers("collection-1", []string{"member-1", "member-2", "member-3"}); err != nil {
+	if err := newTestClient(t, ts).RemoveCollectionMembers(context.Background(), "collection-1", []string{"member-1", "member-2", "member-3"}); err != nil {
 		t.Fatalf("RemoveCollectionMembers: %v", err)
 	}
 	if want := []string{"member-1", "member-2", "member-3"}; !reflect.DeepEqual(gotMembers, want) {
 		t.Fatalf("removed members = %v, want %v", gotMembers, want)
 	}
 }
+
+func TestUpsertCollectionSendsSourceOnlyWhenSet(t *testing.T) {
+	var gotBody map[string]any
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/-net/api/v0/collection/upsert" {
+			t.Errorf("path = %s, want /-net/api/v0/collection/upsert", r.URL.Path)
+		}
+		gotBody = nil
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("server could not decode collection: %v", err)
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	if err := c.UpsertCollection(context.Background(), "collection-1", "Static", "", nil, nil); err != nil {
+		t.Fatalf("UpsertCollection: %v", err)
+	}
+	for _, key := range []string{"source", "source_id"} {
+		if _, present := gotBody[key]; present {
+			t.Errorf("%s was sent for a static collection", key)
+		}
+	}
+
+	source, sourceID := "zoom", "zoom-meetings"
+	if err := c.UpsertCollection(context.Background(), "collection-2", "Synced", "", &source, &sourceID); err != nil {
+		t.Fatalf("UpsertCollection: %v", err)
+	}
+	if gotBody["source"] != source || gotBody["source_id"] != sourceID {
+		t.Errorf("source = %v, source_id = %v, want %s and %s", gotBody["source"], gotBody["source_id"], source, sourceID)
+	}
+}
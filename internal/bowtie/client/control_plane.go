@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -89,8 +90,8 @@ type Controller struct {
 	IPV6                string   `json:"ipv6"`
 }
 
-func (c *Client) GetOrganization() (*Organization, error) {
-	req, err := http.NewRequest(http.MethodGet, c.getHostURL("/organization"), nil)
+func (c *Client) GetOrganization(ctx context.Context) (*Organization, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getHostURL("/organization"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +107,7 @@ func (c *Client) GetOrganization() (*Organization, error) {
 	return org, err
 }
 
-func (c *Client) UpsertOrganization(name string, domain string) error {
+func (c *Client) UpsertOrganization(ctx context.Context, name string, domain string) error {
 	payload := OrganizationPayload{
 		Name:   name,
 		Domain: domain,
@@ -117,7 +118,7 @@ func (c *Client) UpsertOrganization(name string, domain string) error {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/organization"), strings.NewReader(string(requestPayload)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/organization"), strings.NewReader(string(requestPayload)))
 	if err != nil {
 		return err
 	}
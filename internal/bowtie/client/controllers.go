@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,59 +17,178 @@ type TaggedValue struct {
 	Value *string `json:"value,omitempty"`
 }
 
+// BackupStrategy is one destination a Controller writes its backups to. It
+// serializes as {"type": "<variant>", "value": {...}}, where the value holds
+// the settings of that variant: "s3" uses Bucket, Prefix, Region and Endpoint,
+// and "directory" uses Path. Credentials are never part of the strategy; the
+// Controller uses those of its host.
+type BackupStrategy struct {
+	Type  string         `json:"type"`
+	Value BackupLocation `json:"value"`
+}
+
+// BackupLocation is the content of a BackupStrategy. Members the variant does
+// not use are omitted.
+type BackupLocation struct {
+	Bucket   *string `json:"bucket,omitempty"`
+	Prefix   *string `json:"prefix,omitempty"`
+	Region   *string `json:"region,omitempty"`
+	Endpoint *string `json:"endpoint,omitempty"`
+	Path     *string `json:"path,omitempty"`
+
+	// unmanaged holds the members this client does not model, so that a
+	// strategy read from the Controller is posted back unchanged.
+	unmanaged map[string]json.RawMessage
+}
+
+// backupLocationFields aliases BackupLocation without its JSON methods.
+type backupLocationFields BackupLocation
+
+func (l *BackupLocation) UnmarshalJSON(data []byte) error {
+	var fields backupLocationFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for _, known := range []string{"bucket", "prefix", "region", "endpoint", "path"} {
+		delete(members, known)
+	}
+	if len(members) > 0 {
+		fields.unmanaged = members
+	}
+	*l = BackupLocation(fields)
+	return nil
+}
+
+func (l BackupLocation) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(backupLocationFields(l))
+	if err != nil || len(l.unmanaged) == 0 {
+		return data, err
+	}
+	members := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for name, value := range l.unmanaged {
+		if _, ok := members[name]; !ok {
+			members[name] = value
+		}
+	}
+	return json.Marshal(members)
+}
+
+// MinimumPeersBehavior overrides the organization's peer quorum for a single
+// Controller. It serializes as {"type": "<variant>", "value": <percentage>}:
+// "disabled" lets destructive cleanup proceed without a quorum and omits the
+// value, while "percentage" requires Value percent of the Controller's peers.
+type MinimumPeersBehavior struct {
+	Type  string `json:"type"`
+	Value *int   `json:"value,omitempty"`
+}
+
 // ControllerSettings is the full ControllerRepresentation as served by
 // GET/POST /organization/controller. The update endpoint overlays the posted
 // payload onto the existing record, so callers must read the current
 // representation, change only the fields they manage, and post the whole thing
 // back. Server-computed and unmanaged fields are kept as json.RawMessage so
-// they round-trip unchanged.
+// they round-trip unchanged. A nil BackupStrategies or MinimumPeersBehavior
+// posts null, which clears the Controller's override, but only to a
+// Controller that served the member; otherwise it is left out.
 type ControllerSettings struct {
-	ID                              string          `json:"id"`
-	SiteID                          *string         `json:"site_id"`
-	PublicAddress                   string          `json:"public_address"`
-	SyncState                       json.RawMessage `json:"sync_state,omitempty"`
-	Status                          json.RawMessage `json:"status,omitempty"`
-	Features                        []string        `json:"features,omitempty"`
-	WireguardPort                   int             `json:"wireguard_port"`
-	WireguardAddress                *string         `json:"wireguard_address"`
-	PublicKey                       string          `json:"public_key"`
-	HTTPSEndpoint                   string          `json:"https_endpoint"`
-	PersistentKeepalive             int             `json:"persistent_keepalive"`
-	DeviceID                        *string         `json:"device_id"`
-	IPV6                            *string         `json:"ipv6"`
-	IPV4                            json.RawMessage `json:"ipv4,omitempty"`
-	SyncAddress                     *string         `json:"sync_address"`
-	VersionStrategy                 TaggedValue     `json:"version_strategy"`
-	VersionStrategySplay            *TaggedValue    `json:"version_strategy_splay"`
-	VersionIncludePrereleases       *bool           `json:"version_include_prereleases"`
-	VersionMinimumAge               *int            `json:"version_minimum_age"`
-	WireguardStrategy               TaggedValue     `json:"wireguard_strategy"`
-	BackupStrategies                json.RawMessage `json:"backup_strategies,omitempty"`
-	CanUseVanityDomain              bool            `json:"can_use_vanity_domain"`
-	CanUsePublicHTTPS               bool            `json:"can_use_public_https"`
-	CanUseIDP                       bool            `json:"can_use_idp"`
-	CurrentVersion                  *string         `json:"current_version"`
-	TrackPolicyVerdictMetrics       *bool           `json:"track_policy_verdict_metrics"`
-	TrackPolicyVerdictLogs          *bool           `json:"track_policy_verdict_logs"`
-	WebFilterTrustedProxyCollection *string         `json:"web_filter_trusted_proxy_collection"`
-	MinimumPeersBehavior            json.RawMessage `json:"minimum_peers_behavior,omitempty"`
-	AllowTemporaryConsoleUsers      bool            `json:"allow_temporary_console_users"`
-	SSHListener                     *string         `json:"ssh_listener"`
+	ID                              string                `json:"id"`
+	SiteID                          *string               `json:"site_id"`
+	PublicAddress                   string                `json:"public_address"`
+	SyncState                       json.RawMessage       `json:"sync_state,omitempty"`
+	Status                          json.RawMessage       `json:"status,omitempty"`
+	Features                        []string              `json:"features,omitempty"`
+	WireguardPort                   int                   `json:"wireguard_port"`
+	WireguardAddress                *string               `json:"wireguard_address"`
+	PublicKey                       string                `json:"public_key"`
+	HTTPSEndpoint                   string                `json:"https_endpoint"`
+	PersistentKeepalive             int                   `json:"persistent_keepalive"`
+	DeviceID                        *string               `json:"device_id"`
+	IPV6                            *string               `json:"ipv6"`
+	IPV4                            json.RawMessage       `json:"ipv4,omitempty"`
+	SyncAddress                     *string               `json:"sync_address"`
+	VersionStrategy                 TaggedValue           `json:"version_strategy"`
+	VersionStrategySplay            *TaggedValue          `json:"version_strategy_splay"`
+	VersionIncludePrereleases       *bool                 `json:"version_include_prereleases"`
+	VersionMinimumAge               *int                  `json:"version_minimum_age"`
+	WireguardStrategy               TaggedValue           `json:"wireguard_strategy"`
+	BackupStrategies                []BackupStrategy      `json:"backup_strategies"`
+	CanUseVanityDomain              bool                  `json:"can_use_vanity_domain"`
+	CanUsePublicHTTPS               bool                  `json:"can_use_public_https"`
+	CanUseIDP                       bool                  `json:"can_use_idp"`
+	CurrentVersion                  *string               `json:"current_version"`
+	TrackPolicyVerdictMetrics       *bool                 `json:"track_policy_verdict_metrics"`
+	TrackPolicyVerdictLogs          *bool                 `json:"track_policy_verdict_logs"`
+	WebFilterTrustedProxyCollection *string               `json:"web_filter_trusted_proxy_collection"`
+	MinimumPeersBehavior            *MinimumPeersBehavior `json:"minimum_peers_behavior"`
+	AllowTemporaryConsoleUsers      bool                  `json:"allow_temporary_console_users"`
+	SSHListener                     *string               `json:"ssh_listener"`
+
+	// served holds the overrides the Controller's representation carried when
+	// it was read.
+	served map[string]bool
+}
+
+// controllerOverrides are the members of ControllerSettings that are posted as
+// null only to a Controller that served them.
+var controllerOverrides = []string{"backup_strategies", "minimum_peers_behavior"}
+
+// controllerSettingsFields aliases ControllerSettings without its JSON methods.
+type controllerSettingsFields ControllerSettings
+
+func (s *ControllerSettings) UnmarshalJSON(data []byte) error {
+	var fields controllerSettingsFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	fields.served = map[string]bool{}
+	for _, name := range controllerOverrides {
+		_, fields.served[name] = members[name]
+	}
+	*s = ControllerSettings(fields)
+	return nil
+}
+
+func (s ControllerSettings) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(controllerSettingsFields(s))
+	if err != nil {
+		return nil, err
+	}
+	members := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for _, name := range controllerOverrides {
+		if string(members[name]) == "null" && !s.served[name] {
+			delete(members, name)
+		}
+	}
+	return json.Marshal(members)
 }
 
 // ListControllers returns every Controller registered in the organization.
-func (c *Client) ListControllers() ([]ControllerSettings, error) {
+func (c *Client) ListControllers(ctx context.Context) ([]ControllerSettings, error) {
 	var controllers []ControllerSettings
-	if err := c.getListJSON(&controllers, "/organization/controller", "/organization/controller/"); err != nil {
+	if err := c.getListJSON(ctx, &controllers, "/organization/controller", "/organization/controller/"); err != nil {
 		return nil, err
 	}
 	return controllers, nil
 }
 
 // GetController reads a single Controller's full representation by ID.
-func (c *Client) GetController(id string) (*ControllerSettings, error) {
+func (c *Client) GetController(ctx context.Context, id string) (*ControllerSettings, error) {
 	var controller ControllerSettings
-	if err := c.getListJSON(&controller, fmt.Sprintf("/organization/controller/%s", id)); err != nil {
+	if err := c.getListJSON(ctx, &controller, fmt.Sprintf("/organization/controller/%s", id)); err != nil {
 		return nil, err
 	}
 	return &controller, nil
@@ -77,13 +197,13 @@ func (c *Client) GetController(id string) (*ControllerSettings, error) {
 // UpdateController posts a full ControllerRepresentation. The Controller must
 // already exist (Controllers self-register at boot); the endpoint returns 404
 // otherwise. The id member of the payload selects the Controller to update.
-func (c *Client) UpdateController(settings *ControllerSettings) (*ControllerSettings, error) {
+func (c *Client) UpdateController(ctx context.Context, settings *ControllerSettings) (*ControllerSettings, error) {
 	body, err := json.Marshal(settings)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/organization/controller"), strings.NewReader(string(body)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/organization/controller"), strings.NewReader(string(body)))
 	if err != nil {
 		return nil, err
 	}
@@ -101,8 +221,8 @@ func (c *Client) UpdateController(settings *ControllerSettings) (*ControllerSett
 }
 
 // DeleteController removes a Controller record from the control plane.
-func (c *Client) DeleteController(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/organization/controller/%s", id)), nil)
+func (c *Client) DeleteController(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/organization/controller/%s", id)), nil)
 	if err != nil {
 		return err
 	}
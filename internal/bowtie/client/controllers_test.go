@@ -1,6 +1,10 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -13,11 +17,108 @@ func TestGetControllerNotFoundReturnsHTTP404(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	_, err := newTestClient(t, ts).GetController("missing")
+	_, err := newTestClient(t, ts).GetController(context.Background(), "missing")
 	if err == nil {
 		t.Fatal("expected GetController to return an error")
 	}
 	if !strings.Contains(err.Error(), "HTTP 404") {
 		t.Fatalf("expected HTTP 404 error, got %q", err.Error())
 	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the error to match ErrNotFound, got %v", err)
+	}
+}
+
+func TestUpdateControllerRoundTripsBackupStrategies(t *testing.T) {
+	stored := `{
+		"id": "controller-1",
+		"backup_strategies": [
+			{"type": "s3", "value": {"bucket": "dr-backups", "region": "us-east-1", "storage_class": "GLACIER"}},
+			{"type": "directory", "value": {"path": "/var/backups/bowtie"}}
+		],
+		"minimum_peers_behavior": {"type": "percentage", "value": 60}
+	}`
+	var posted map[string]json.RawMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &posted); err != nil {
+				t.Errorf("decoding posted controller: %v", err)
+			}
+			_, _ = w.Write(body)
+			return
+		}
+		_, _ = w.Write([]byte(stored))
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	controller, err := c.GetController(context.Background(), "controller-1")
+	if err != nil {
+		t.Fatalf("GetController: %v", err)
+	}
+	if got := len(controller.BackupStrategies); got != 2 {
+		t.Fatalf("decoded %d backup strategies, want 2", got)
+	}
+	if bucket := controller.BackupStrategies[0].Value.Bucket; bucket == nil || *bucket != "dr-backups" {
+		t.Fatalf("bucket = %v, want dr-backups", bucket)
+	}
+	if peers := controller.MinimumPeersBehavior; peers == nil || peers.Type != "percentage" || peers.Value == nil || *peers.Value != 60 {
+		t.Fatalf("minimum_peers_behavior = %+v, want percentage 60", peers)
+	}
+
+	// Members the client does not model survive a read-modify-write.
+	if _, err := c.UpdateController(context.Background(), controller); err != nil {
+		t.Fatalf("UpdateController: %v", err)
+	}
+	if !strings.Contains(string(posted["backup_strategies"]), `"storage_class":"GLACIER"`) {
+		t.Errorf("posted backup_strategies %s dropped an unmodeled member", posted["backup_strategies"])
+	}
+
+	// Clearing the overrides posts null rather than leaving them out.
+	controller.BackupStrategies = nil
+	controller.MinimumPeersBehavior = nil
+	if _, err := c.UpdateController(context.Background(), controller); err != nil {
+		t.Fatalf("UpdateController: %v", err)
+	}
+	for _, field := range []string{"backup_strategies", "minimum_peers_behavior"} {
+		if value, ok := posted[field]; !ok || string(value) != "null" {
+			t.Errorf("posted %s = %s, want null", field, value)
+		}
+	}
+}
+
+func TestUpdateControllerLeavesOutUnservedOverrides(t *testing.T) {
+	// A Controller that predates backup strategies and minimum peers behavior.
+	stored := `{"id": "controller-1", "public_address": "203.0.113.10", "version_minimum_age": null}`
+	var posted map[string]json.RawMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &posted); err != nil {
+				t.Errorf("decoding posted controller: %v", err)
+			}
+			_, _ = w.Write(body)
+			return
+		}
+		_, _ = w.Write([]byte(stored))
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	controller, err := c.GetController(context.Background(), "controller-1")
+	if err != nil {
+		t.Fatalf("GetController: %v", err)
+	}
+	if _, err := c.UpdateController(context.Background(), controller); err != nil {
+		t.Fatalf("UpdateController: %v", err)
+	}
+	for _, field := range []string{"backup_strategies", "minimum_peers_behavior"} {
+		if value, ok := posted[field]; ok {
+			t.Errorf("posted %s = %s to a Controller that never served it", field, value)
+		}
+	}
+	if value := posted["public_address"]; string(value) != `"203.0.113.10"` {
+		t.Errorf("posted public_address = %s, want the value read", value)
+	}
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -24,15 +25,15 @@ type deviceGroupUpsert struct {
 	Description *string `json:"description"`
 }
 
-func (c *Client) GetDeviceGroups() (map[string]BowtieDeviceGroup, error) {
+func (c *Client) GetDeviceGroups(ctx context.Context) (map[string]BowtieDeviceGroup, error) {
 	groups := map[string]BowtieDeviceGroup{}
-	if err := c.getListJSON(&groups, "/device_group/", "/device_group"); err != nil {
+	if err := c.getListJSON(ctx, &groups, "/device_group/", "/device_group"); err != nil {
 		return nil, err
 	}
 	return groups, nil
 }
 
-func (c *Client) UpsertDeviceGroup(id, name string, description *string) error {
+func (c *Client) UpsertDeviceGroup(ctx context.Context, id, name string, description *string) error {
 	payload, err := json.Marshal(deviceGroupUpsert{
 		ID:          id,
 		Name:        name,
@@ -42,7 +43,7 @@ func (c *Client) UpsertDeviceGroup(id, name string, description *string) error {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/device_group/upsert"), bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/device_group/upsert"), bytes.NewBuffer(payload))
 	if err != nil {
 		return err
 	}
@@ -51,8 +52,61 @@ func (c *Client) UpsertDeviceGroup(id, name string, description *string) error {
 	return err
 }
 
-func (c *Client) DeleteDeviceGroup(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/device_group/%s", id)), nil)
+// DeviceGroupMembership is a device group with the IDs of its member devices,
+// as served by /device_group/<id>/list.
+type DeviceGroupMembership struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Devices []string `json:"devices"`
+}
+
+type setDeviceGroupMembershipPayload struct {
+	Devices []map[string]string `json:"devices"`
+}
+
+// ListDevicesInGroup reads the member devices of a device group.
+func (c *Client) ListDevicesInGroup(ctx context.Context, id string) (*DeviceGroupMembership, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getHostURL(fmt.Sprintf("/device_group/%s/list", id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	membership := &DeviceGroupMembership{}
+	if err := json.Unmarshal(body, membership); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// SetDeviceGroupMembership replaces the members of a device group with the
+// given devices. An empty list removes every device from the group.
+func (c *Client) SetDeviceGroupMembership(ctx context.Context, groupID string, devices []string) error {
+	payload := setDeviceGroupMembershipPayload{Devices: []map[string]string{}}
+	for _, id := range devices {
+		payload.Devices = append(payload.Devices, map[string]string{"id": id})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL(fmt.Sprintf("/device_group/%s/set_membership", groupID)), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) DeleteDeviceGroup(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/device_group/%s", id)), nil)
 	if err != nil {
 		return err
 	}
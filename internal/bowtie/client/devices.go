@@ -1,6 +1,8 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,8 +28,57 @@ type Device struct {
 	LastSeenVersion string `json:"last_seen_version"`
 }
 
-func (c *Client) DeleteDevice(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/device/%s", id)), nil)
+// deviceUpsert is the request body for /device/upsert. It changes only the
+// lifecycle fields of an existing device: its enrollment state, the user it is
+// assigned to, and the organization that owns it. Other members are preserved
+// server-side; a null assigned_to_user or owned_by_org clears the assignment
+// or the ownership.
+type deviceUpsert struct {
+	ID             string  `json:"id"`
+	State          string  `json:"state"`
+	AssignedToUser *string `json:"assigned_to_user"`
+	OwnedByOrg     *string `json:"owned_by_org"`
+}
+
+// GetDevice reads a single device by ID. It returns an error matching
+// ErrNotFound when no device has the ID.
+func (c *Client) GetDevice(ctx context.Context, id string) (Device, error) {
+	devices, err := c.ListDevices(ctx)
+	if err != nil {
+		return Device{}, err
+	}
+	device, ok := devices[id]
+	if !ok {
+		return Device{}, fmt.Errorf("device %s: %w", id, ErrNotFound)
+	}
+	return device, nil
+}
+
+// UpdateDeviceLifecycle sets the enrollment state, user assignment and owning
+// organization of an existing device. Devices enroll themselves, so the
+// device must already exist.
+func (c *Client) UpdateDeviceLifecycle(ctx context.Context, id, state string, assignedToUser, ownedByOrg *string) error {
+	payload, err := json.Marshal(deviceUpsert{
+		ID:             id,
+		State:          state,
+		AssignedToUser: assignedToUser,
+		OwnedByOrg:     ownedByOrg,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/device/upsert"), bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
+
+func (c *Client) DeleteDevice(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/device/%s", id)), nil)
 	if err != nil {
 		return err
 	}
@@ -36,8 +87,8 @@ func (c *Client) DeleteDevice(id string) error {
 	return err
 }
 
-func (c *Client) ListDevices() (map[string]Device, error) {
-	req, err := http.NewRequest(http.MethodGet, c.getHostURL("/device"), nil)
+func (c *Client) ListDevices(ctx context.Context) (map[string]Device, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getHostURL("/device"), nil)
 	if err != nil {
 		return nil, err
 	}
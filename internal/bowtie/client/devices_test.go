@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetDeviceNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"devices": {"device-1": {"id": "device-1", "serial": "C02XK0ABJG5J"}}}`))
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	device, err := c.GetDevice(context.Background(), "device-1")
+	if err != nil || device.Serial != "C02XK0ABJG5J" {
+		t.Fatalf("GetDevice = %+v, %v", device, err)
+	}
+	if _, err := c.GetDevice(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the error to match ErrNotFound, got %v", err)
+	}
+}
+
+func TestUpdateDeviceLifecycleSendsNullToClear(t *testing.T) {
+	var posted map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/-net/api/v0/device/upsert" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	user := "user-1"
+	if err := c.UpdateDeviceLifecycle(context.Background(), "device-1", "accepted", &user, nil); err != nil {
+		t.Fatalf("UpdateDeviceLifecycle: %v", err)
+	}
+	if posted["id"] != "device-1" || posted["state"] != "accepted" || posted["assigned_to_user"] != "user-1" {
+		t.Errorf("posted %v", posted)
+	}
+	if value, ok := posted["owned_by_org"]; !ok || value != nil {
+		t.Errorf("owned_by_org = %v (present %t), want null", value, ok)
+	}
+}
+
+func TestSetDeviceGroupMembershipSendsEmptyList(t *testing.T) {
+	var posted string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/-net/api/v0/device_group/group-1/set_membership" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		posted = string(body)
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts)
+
+	if err := c.SetDeviceGroupMembership(context.Background(), "group-1", []string{"device-1"}); err != nil {
+		t.Fatalf("SetDeviceGroupMembership: %v", err)
+	}
+	if posted != `{"devices":[{"id":"device-1"}]}` {
+		t.Errorf("posted %s", posted)
+	}
+
+	// Emptying the group must send an empty list, not null.
+	if err := c.SetDeviceGroupMembership(context.Background(), "group-1", nil); err != nil {
+		t.Fatalf("SetDeviceGroupMembership: %v", err)
+	}
+	if posted != `{"devices":[]}` {
+		t.Errorf("posted %s, want an empty device list", posted)
+	}
+}
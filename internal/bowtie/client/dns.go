@@ -2,12 +2,13 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
-func (c *Client) UpsertDNS(id, name string, serverAddrs []Server, includeOnlySites []string, isDNS64, isCounted, isLog, isDropA, isDropAll, isSearchDomain bool, exlude []DNSExclude) error {
+func (c *Client) UpsertDNS(ctx context.Context, id, name string, serverAddrs []Server, includeOnlySites []string, isDNS64, isCounted, isLog, isDropA, isDropAll, isSearchDomain bool, exlude []DNSExclude) error {
 	var servers map[string]Server = map[string]Server{}
 	for _, addr := range serverAddrs {
 		servers[addr.ID] = addr
@@ -36,7 +37,7 @@ func (c *Client) UpsertDNS(id, name string, serverAddrs []Server, includeOnlySit
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/organization/dns/upsert"), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/organization/dns/upsert"), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -45,8 +46,8 @@ func (c *Client) UpsertDNS(id, name string, serverAddrs []Server, includeOnlySit
 	return err
 }
 
-func (c *Client) DeleteDNS(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/organization/dns/%s", id)), nil)
+func (c *Client) DeleteDNS(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/organization/dns/%s", id)), nil)
 	if err != nil {
 		return err
 	}
@@ -55,8 +56,8 @@ func (c *Client) DeleteDNS(id string) error {
 	return err
 }
 
-func (c *Client) GetDNS() (map[string]DNS, error) {
-	org, err := c.GetOrganization()
+func (c *Client) GetDNS(ctx context.Context) (map[string]DNS, error) {
+	org, err := c.GetOrganization(ctx)
 	if err != nil {
 		return nil, err
 	}
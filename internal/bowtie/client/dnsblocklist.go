@@ -2,18 +2,24 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
-func (c *Client) UpsertDNSBlockList(id string, name string, upstream string, override_to_allow string) error {
+// blockListFetchTimeout is the least time an upsert is given to answer: the
+// Controller fetches the upstream list to validate it before responding.
+const blockListFetchTimeout = 30 * time.Second
+
+func (c *Client) UpsertDNSBlockList(ctx context.Context, id string, name string, upstream string, override_to_allow string, is_allowlist bool) error {
 	var payload DNSBlockList = DNSBlockList{
 		ID:              id,
 		Name:            name,
 		Upstream:        upstream,
 		OverrideToAllow: override_to_allow,
-		IsAllowlist:     false,
+		IsAllowlist:     is_allowlist,
 	}
 
 	body, err := json.Marshal(payload)
@@ -21,7 +27,8 @@ func (c *Client) UpsertDNSBlockList(id string, name string, upstream string, ove
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/dns_block_list"), bytes.NewBuffer(body))
+	ctx = withMinRequestTimeout(ctx, blockListFetchTimeout)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/dns_block_list"), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -30,8 +37,8 @@ func (c *Client) UpsertDNSBlockList(id string, name string, upstream string, ove
 	return err
 }
 
-func (c *Client) DeleteDNSBlockList(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/dns_block_list/%s", id)), nil)
+func (c *Client) DeleteDNSBlockList(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/dns_block_list/%s", id)), nil)
 	if err != nil {
 		return err
 	}
@@ -40,8 +47,8 @@ func (c *Client) DeleteDNSBlockList(id string) error {
 	return err
 }
 
-func (c *Client) GetDNSBlockLists() (map[string]DNSBlockList, error) {
-	req, err := http.NewRequest(http.MethodGet, c.getHostURL("/dns_block_list"), nil)
+func (c *Client) GetDNSBlockLists(ctx context.Context) (map[string]DNSBlockList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getHostURL("/dns_block_list"), nil)
 	if err != nil {
 		return nil, err
 	}
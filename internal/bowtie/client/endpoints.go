@@ -0,0 +1,155 @@
+package client
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// WithFailoverHosts adds Controller endpoints to try, in order, when the host
+// passed to NewClient is unreachable or failing. Every endpoint must serve the
+// same organization, such as the Controllers of one site.
+func WithFailoverHosts(hosts ...string) Option {
+	return func(o *clientOptions) {
+		o.failoverHosts = append(o.failoverHosts, hosts...)
+	}
+}
+
+// SplitHosts parses a comma-separated list of Controller endpoints, as found
+// in the BOWTIE_HOST environment variable, dropping empty entries.
+func SplitHosts(value string) []string {
+	var hosts []string
+	for _, host := range strings.Split(value, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// endpointOrder returns the Controller endpoints in the order a request tries
+// them: the last one known to be healthy first, then the rest in their
+// configured order.
+func (c *Client) endpointOrder() []string {
+	if len(c.endpoints) == 0 {
+		return []string{c.hostURL}
+	}
+
+	c.endpointMu.Lock()
+	preferred := c.preferred
+	c.endpointMu.Unlock()
+
+	order := make([]string, 0, len(c.endpoints))
+	order = append(order, c.endpoints[preferred])
+	for i, endpoint := range c.endpoints {
+		if i != preferred {
+			order = append(order, endpoint)
+		}
+	}
+	return order
+}
+
+// markHealthy makes endpoint the first one later requests try.
+func (c *Client) markHealthy(endpoint string) {
+	c.endpointMu.Lock()
+	defer c.endpointMu.Unlock()
+
+	for i, candidate := range c.endpoints {
+		if candidate == endpoint {
+			c.preferred = i
+			return
+		}
+	}
+}
+
+// endpointURL rebases u, which getHostURL built against the primary host, onto
+// endpoint.
+func (c *Client) endpointURL(u *url.URL, endpoint string) (*url.URL, error) {
+	rawURL := u.String()
+	if endpoint == c.hostURL || !strings.HasPrefix(rawURL, c.hostURL) {
+		return u, nil
+	}
+	return url.Parse(endpoint + strings.TrimPrefix(rawURL, c.hostURL))
+}
+
+// logFailover records that a request is moving on from endpoint.
+func (c *Client) logFailover(req *http.Request, endpoint string, res *http.Response, err error) {
+	fields := map[string]interface{}{
+		"method":   req.Method,
+		"path":     req.URL.RequestURI(),
+		"endpoint": endpoint,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["status"] = res.StatusCode
+	}
+	tflog.SubsystemWarn(c.logContext(req.Context()), logSubsystem, "Controller endpoint failed, trying the next one", fields)
+}
+
+// shouldFailover reports whether a request that ended with res or err should
+// move on to the next Controller endpoint. A connection that could not be
+// established never reached the Controller, so any request may fail over; a
+// server error or a broken connection only allows it for idempotent requests.
+func shouldFailover(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isDialError(err) || isIdempotent(req)
+	}
+	return res.StatusCode >= 500 && isIdempotent(req)
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// endpointJar keeps a separate cookie jar for every host and port, so that
+// each Controller endpoint holds its own session even when several of them
+// share a hostname. A plain cookiejar.Jar ignores ports.
+type endpointJar struct {
+	mu   sync.Mutex
+	jars map[string]*cookiejar.Jar
+}
+
+func newEndpointJar() *endpointJar {
+	return &endpointJar{jars: map[string]*cookiejar.Jar{}}
+}
+
+func (j *endpointJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar(u).SetCookies(u, cookies)
+}
+
+func (j *endpointJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar(u).Cookies(u)
+}
+
+func (j *endpointJar) jar(u *url.URL) *cookiejar.Jar {
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	key := net.JoinHostPort(strings.ToLower(u.Hostname()), port)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	jar, ok := j.jars[key]
+	if !ok {
+		// cookiejar.New only fails on invalid options.
+		jar, _ = cookiejar.New(nil)
+		j.jars[key] = jar
+	}
+	return jar
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// controllerServer serves a Controller endpoint with its own sessions. Every
+// request other than a login is answered with status once authorized.
+func controllerServer(t *testing.T, sessions *sessionServer, status int) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/-net/api/v0/user/login" {
+			sessions.login(w)
+			return
+		}
+		if !sessions.authorized(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestFailoverOnUnreachableEndpoint(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	sessions := &sessionServer{}
+	up := controllerServer(t, sessions, http.StatusOK)
+
+	c, err := NewClient(context.Background(), down.URL, "admin@example.com", "hunter2", true, true, false, "",
+		WithFailoverHosts(up.URL), WithRetryPolicy(RetryPolicy{}))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	// Even a request that is not safe to repeat fails over, since it never
+	// reached the unreachable Controller.
+	if _, err := c.AddUserToGroup(context.Background(), "g-1", []string{"u-1"}); err != nil {
+		t.Fatalf("AddUserToGroup: %v", err)
+	}
+	if got := c.endpointOrder()[0]; got != up.URL {
+		t.Errorf("preferred endpoint = %s, want the healthy %s", got, up.URL)
+	}
+	if _, err := c.GetGroups(context.Background()); err != nil {
+		t.Fatalf("GetGroups: %v", err)
+	}
+	if sessions.logins != 1 {
+		t.Errorf("logins = %d, want the session with the healthy endpoint reused", sessions.logins)
+	}
+}
+
+func TestFailoverOnServerErrorOnlyForIdempotentRequests(t *testing.T) {
+	primary := controllerServer(t, &sessionServer{}, http.StatusInternalServerError)
+	secondarySessions := &sessionServer{}
+	secondary := controllerServer(t, secondarySessions, http.StatusOK)
+
+	c, err := NewClient(context.Background(), primary.URL, "admin@example.com", "hunter2", true, true, false, "",
+		WithFailoverHosts(secondary.URL), WithRetryPolicy(RetryPolicy{}))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	_, err = c.AddUserToGroup(context.Background(), "g-1", []string{"u-1"})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 500") {
+		t.Fatalf("expected the primary's HTTP 500 for a non-idempotent request, got %v", err)
+	}
+	if secondarySessions.logins != 0 {
+		t.Errorf("a non-idempotent request failed over after reaching the Controller")
+	}
+
+	if _, err := c.GetGroups(context.Background()); err != nil {
+		t.Fatalf("GetGroups: %v", err)
+	}
+	if got := c.endpointOrder()[0]; got != secondary.URL {
+		t.Errorf("preferred endpoint = %s, want %s", got, secondary.URL)
+	}
+}
+
+func TestEndpointJarKeepsSessionsPerPort(t *testing.T) {
+	jar := newEndpointJar()
+	first, _ := url.Parse("https://controller.example.com:8443/")
+	second, _ := url.Parse("https://controller.example.com/")
+
+	jar.SetCookies(first, []*http.Cookie{{Name: "session", Value: "first"}})
+
+	if got := jar.Cookies(second); len(got) != 0 {
+		t.Errorf("cookies for another port = %v, want none", got)
+	}
+	if got := jar.Cookies(first); len(got) != 1 || got[0].Value != "first" {
+		t.Errorf("cookies = %v, want the first endpoint's session", got)
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for the failure classes callers act on. Match them with
+// errors.Is against any error returned by the client: an *APIError matches
+// the sentinel for its status code, and lookups that find no matching object
+// in a list response wrap ErrNotFound.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// maxErrorBody bounds how much of an error response is kept on an APIError.
+const maxErrorBody = 4096
+
+// APIError describes a non-success response from the Controller.
+type APIError struct {
+	StatusCode  int
+	Method      string
+	Path        string
+	ContentType string
+	// Message is the error reported by the Controller, decoded from the
+	// response body when it carries one.
+	Message string
+	// Body is the raw response body, truncated to maxErrorBody bytes.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		detail = e.Body
+	}
+	return fmt.Sprintf("%s %s: HTTP %d %s (%s): %s",
+		e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.ContentType, detail)
+}
+
+// Is reports whether the error belongs to one of the sentinel classes.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	default:
+		return false
+	}
+}
+
+// newAPIError builds the error for a non-success response to req.
+func newAPIError(req *http.Request, res *http.Response, body []byte) *APIError {
+	snippet := string(body)
+	if len(snippet) > maxErrorBody {
+		snippet = snippet[:maxErrorBody] + "…[truncated]"
+	}
+
+	return &APIError{
+		StatusCode:  res.StatusCode,
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		ContentType: res.Header.Get("Content-Type"),
+		Message:     decodeErrorMessage(body),
+		Body:        strings.TrimSpace(snippet),
+	}
+}
+
+// decodeErrorMessage extracts the human-readable message from a JSON error
+// body: either a bare JSON string or an object with an error, message or
+// detail member. It returns the empty string for anything else.
+func decodeErrorMessage(body []byte) string {
+	var message string
+	if err := json.Unmarshal(body, &message); err == nil {
+		return strings.TrimSpace(message)
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return ""
+	}
+	for _, key := range []string{"error", "message", "detail"} {
+		if raw, ok := object[key]; ok {
+			if err := json.Unmarshal(raw, &message); err == nil && message != "" {
+				return strings.TrimSpace(message)
+			}
+		}
+	}
+	return ""
+}
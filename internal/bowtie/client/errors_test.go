@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIErrorCarriesResponseDetails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error": "a group named Engineering already exists"}`))
+	}))
+	defer ts.Close()
+
+	_, err := newTestClient(t, ts).UpsertGroup(context.Background(), "g-1", "Engineering")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusConflict || apiErr.Method != http.MethodPost || apiErr.Path != "/-net/api/v0/group/upsert" {
+		t.Errorf("unexpected request details: %+v", apiErr)
+	}
+	if apiErr.ContentType != "application/json" {
+		t.Errorf("ContentType = %q", apiErr.ContentType)
+	}
+	if apiErr.Message != "a group named Engineering already exists" {
+		t.Errorf("Message = %q", apiErr.Message)
+	}
+	if !errors.Is(err, ErrConflict) || errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is mismatched for a 409: %v", err)
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+	}
+	for _, tt := range tests {
+		err := error(&APIError{StatusCode: tt.status})
+		for _, sentinel := range []error{ErrNotFound, ErrConflict, ErrUnauthorized, ErrForbidden} {
+			if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+				t.Errorf("errors.Is(HTTP %d, %v) = %v", tt.status, sentinel, got)
+			}
+		}
+	}
+}
+
+func TestLookupMissesWrapErrNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"policies": {}, "resource_groups": {}, "resources": {}}`))
+	}))
+	defer ts.Close()
+
+	if _, err := newTestClient(t, ts).GetPolicy(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := FindSite("missing", nil); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDecodeErrorMessage(t *testing.T) {
+	tests := map[string]string{
+		`"plain string"`:               "plain string",
+		`{"message": "bad request"}`:   "bad request",
+		`{"detail": "no such site"}`:   "no such site",
+		`{"unrelated": true}`:          "",
+		`<html>Bad Gateway</html>`:     "",
+		`{"error": {"nested": "obj"}}`: "",
+	}
+	for body, want := range tests {
+		if got := decodeErrorMessage([]byte(body)); got != want {
+			t.Errorf("decodeErrorMessage(%s) = %q, want %q", body, got, want)
+		}
+	}
+}
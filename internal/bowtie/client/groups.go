@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -27,8 +28,8 @@ type SetUserGroupMembershipPayload struct {
 	Users []map[string]string `json:"users"`
 }
 
-func (c *Client) GetGroups() (map[string]Group, error) {
-	groups, err := c.ListGroups()
+func (c *Client) GetGroups(ctx context.Context) (map[string]Group, error) {
+	groups, err := c.ListGroups(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -36,8 +37,8 @@ func (c *Client) GetGroups() (map[string]Group, error) {
 	return groups, nil
 }
 
-func (c *Client) ListGroups() (map[string]Group, error) {
-	req, err := http.NewRequest("GET", c.getHostURL("/group"), nil)
+func (c *Client) ListGroups(ctx context.Context) (map[string]Group, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.getHostURL("/group"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +57,7 @@ func (c *Client) ListGroups() (map[string]Group, error) {
 	return groups, nil
 }
 
-func (c *Client) UpsertGroup(id, name string) (string, error) {
+func (c *Client) UpsertGroup(ctx context.Context, id, name string) (string, error) {
 	groupRequest := Group{
 		Name: name,
 		ID:   id,
@@ -67,7 +68,7 @@ func (c *Client) UpsertGroup(id, name string) (string, error) {
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", c.getHostURL("/group/upsert"), strings.NewReader(string(requestBody)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.getHostURL("/group/upsert"), strings.NewReader(string(requestBody)))
 	if err != nil {
 		return "", err
 	}
@@ -86,8 +87,8 @@ func (c *Client) UpsertGroup(id, name string) (string, error) {
 	return id, nil
 }
 
-func (c *Client) ListUsersInGroup(id string) (*Group, error) {
-	req, err := http.NewRequest("GET", c.getHostURL(fmt.Sprintf("/group/%s/list", id)), nil)
+func (c *Client) ListUsersInGroup(ctx context.Context, id string) (*Group, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.getHostURL(fmt.Sprintf("/group/%s/list", id)), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -106,15 +107,15 @@ func (c *Client) ListUsersInGroup(id string) (*Group, error) {
 	return group, nil
 }
 
-func (c *Client) AddUserToGroup(groupID string, userIDs []string) (*ModifyUserGroupResponse, error) {
-	return c.modifyUserGroup("addusers", groupID, userIDs)
+func (c *Client) AddUserToGroup(ctx context.Context, groupID string, userIDs []string) (*ModifyUserGroupResponse, error) {
+	return c.modifyUserGroup(ctx, "addusers", groupID, userIDs)
 }
 
-func (c *Client) RemoveUserFromGroup(groupID string, userIDs []string) (*ModifyUserGroupResponse, error) {
-	return c.modifyUserGroup("removeusers", groupID, userIDs)
+func (c *Client) RemoveUserFromGroup(ctx context.Context, groupID string, userIDs []string) (*ModifyUserGroupResponse, error) {
+	return c.modifyUserGroup(ctx, "removeusers", groupID, userIDs)
 }
 
-func (c *Client) modifyUserGroup(action, groupID string, userIDs []string) (*ModifyUserGroupResponse, error) {
+func (c *Client) modifyUserGroup(ctx context.Context, action, groupID string, userIDs []string) (*ModifyUserGroupResponse, error) {
 	var userIDPayloads []map[string]string = []map[string]string{}
 	for _, userId := range userIDs {
 		userIDPayloads = append(userIDPayloads, map[string]string{
@@ -129,7 +130,7 @@ func (c *Client) modifyUserGroup(action, groupID string, userIDs []string) (*Mod
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.getHostURL(fmt.Sprintf("/group/%s", action)), strings.NewReader(string(payload)))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.getHostURL(fmt.Sprintf("/group/%s", action)), strings.NewReader(string(payload)))
 	if err != nil {
 		return nil, err
 	}
@@ -145,8 +146,8 @@ func (c *Client) modifyUserGroup(action, groupID string, userIDs []string) (*Mod
 	return response, err
 }
 
-func (c *Client) DeleteGroup(groupID string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/group/%s", groupID)), nil)
+func (c *Client) DeleteGroup(ctx context.Context, groupID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/group/%s", groupID)), nil)
 	if err != nil {
 		return err
 	}
@@ -155,7 +156,7 @@ func (c *Client) DeleteGroup(groupID string) error {
 	return err
 }
 
-func (c *Client) SetGroupMembership(groupID string, users []string) error {
+func (c *Client) SetGroupMembership(ctx context.Context, groupID string, users []string) error {
 	var userIDPayloads []map[string]string = []map[string]string{}
 	for _, userId := range users {
 		userIDPayloads = append(userIDPayloads, map[string]string{
@@ -172,7 +173,7 @@ func (c *Client) SetGroupMembership(groupID string, users []string) error {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL(fmt.Sprintf("/group/%s/set_membership", groupID)), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL(fmt.Sprintf("/group/%s/set_membership", groupID)), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -2,26 +2,69 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/cookiejar"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/time/rate"
 )
 
 type Client struct {
 	HTTPClient       *http.Client
 	Tagged_locations bool
 
-	hostURL   string
+	// hostURL is the primary Controller endpoint, which request URLs are
+	// built against. endpoints lists it followed by the failover endpoints,
+	// and preferred indexes the last one that answered; both are guarded by
+	// endpointMu.
+	hostURL    string
+	endpoints  []string
+	endpointMu sync.Mutex
+	preferred  int
+
 	auth      AuthPayload
 	authCheck sync.Mutex
+	// authEpochs counts, per endpoint, the logins performed on behalf of
+	// doRequest. It is guarded by authCheck and lets concurrent requests that
+	// were rejected by the same expired session share a single new login.
+	authEpochs map[string]uint64
+	// apiToken, when set, is sent as a bearer token on every request in place
+	// of the email and password login session.
+	apiToken string
+	retry    RetryPolicy
+	// requestTimeout bounds each attempt at a request, from sending it to
+	// reading the whole response. Zero leaves attempts unbounded.
+	requestTimeout time.Duration
+	// cache coalesces and caches reads; nil sends every request as is.
+	cache *readCache
+	// limiter and slots throttle the requests sent to the Controller; either
+	// is nil when unlimited.
+	limiter *rate.Limiter
+	slots   chan struct{}
+
+	// detectTaggedLocations makes TaggedLocations follow the Controller's
+	// encoding instead of Tagged_locations.
+	detectTaggedLocations bool
+	// capabilitiesMu guards capabilities and taggedLocations, which hold
+	// what was detected so far.
+	capabilitiesMu  sync.Mutex
+	capabilities    *Capabilities
+	taggedLocations *bool
+	// routes remembers, for each set of alternative paths passed to
+	// doJSONWithFallback, the one the Controller served.
+	routes sync.Map
 }
 
 type AuthPayload struct {
@@ -31,21 +74,109 @@ type AuthPayload struct {
 
 const apiVersionPrefix = "/-net/api/v0"
 
-func NewClient(host, username, password string, lazy_auth, tagged_locations, insecure bool, caBundle string) (*Client, error) {
-	jar, err := cookiejar.New(nil)
-	if err != nil {
-		return nil, err
+// DefaultRequestTimeout is how long a single attempt at a request may take
+// unless WithRequestTimeout says otherwise.
+const DefaultRequestTimeout = 10 * time.Second
+
+// clientOptions collects the optional settings applied by NewClient.
+type clientOptions struct {
+	apiToken       string
+	retry          RetryPolicy
+	requestTimeout time.Duration
+	readCacheTTL   time.Duration
+
+	requestsPerSecond     float64
+	maxConcurrentRequests int
+
+	failoverHosts []string
+
+	clientCertificate string
+	clientKey         string
+
+	proxyURL string
+	noProxy  []string
+
+	detectTaggedLocations bool
+}
+
+// Option customizes a Client built by NewClient.
+type Option func(*clientOptions)
+
+// WithRetryPolicy replaces DefaultRetryPolicy for transient Controller errors.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retry = policy
+	}
+}
+
+// WithRequestTimeout replaces DefaultRequestTimeout. The timeout applies to
+// every attempt separately; a request that is retried can take longer overall,
+// so bound whole operations through the context passed to each method.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.requestTimeout = timeout
+	}
+}
+
+// WithReadCacheTTL replaces DefaultReadCacheTTL. Zero turns off caching;
+// concurrent identical GETs are still coalesced.
+func WithReadCacheTTL(ttl time.Duration) Option {
+	return func(o *clientOptions) {
+		o.readCacheTTL = ttl
+	}
+}
+
+// WithAPIToken authenticates every request with an API token sent as a bearer
+// Authorization header. The client then never logs in with the username and
+// password, which may be left empty.
+func WithAPIToken(token string) Option {
+	return func(o *clientOptions) {
+		o.apiToken = token
+	}
+}
+
+// WithClientCertificate presents a TLS client certificate to the Controller,
+// for Controllers behind a reverse proxy that enforces mutual TLS. Both the
+// certificate and its private key may be inline PEM or a path to a PEM file.
+func WithClientCertificate(certificate, key string) Option {
+	return func(o *clientOptions) {
+		o.clientCertificate = certificate
+		o.clientKey = key
+	}
+}
+
+// WithProxy sends requests to the Controller through proxyURL, an http, https
+// or socks5 URL that may carry credentials, instead of any proxy named by the
+// HTTP_PROXY and HTTPS_PROXY environment variables. Hosts matching noProxy,
+// in the format of the NO_PROXY environment variable, are reached directly.
+// An empty proxyURL keeps the environment's proxy and only adds noProxy.
+func WithProxy(proxyURL string, noProxy ...string) Option {
+	return func(o *clientOptions) {
+		o.proxyURL = proxyURL
+		o.noProxy = noProxy
 	}
+}
 
-	transport, err := buildTransport(insecure, caBundle)
+func NewClient(ctx context.Context, host, username, password string, lazy_auth, tagged_locations, insecure bool, caBundle string, opts ...Option) (*Client, error) {
+	options := clientOptions{
+		retry:          DefaultRetryPolicy,
+		requestTimeout: DefaultRequestTimeout,
+		readCacheTTL:   DefaultReadCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	transport, err := buildTransport(insecure, caBundle, options)
 	if err != nil {
 		return nil, err
 	}
 
+	limiter, slots := newLimits(options)
+
 	c := &Client{
 		HTTPClient: &http.Client{
-			Timeout:   10 * time.Second,
-			Jar:       jar,
+			Jar:       newEndpointJar(),
 			Transport: transport,
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				return http.ErrUseLastResponse
@@ -53,14 +184,23 @@ func NewClient(host, username, password string, lazy_auth, tagged_locations, ins
 		},
 		Tagged_locations: tagged_locations,
 		hostURL:          host,
+		endpoints:        append([]string{host}, options.failoverHosts...),
 		auth: AuthPayload{
 			Username: username,
 			Password: password,
 		},
+		apiToken:       options.apiToken,
+		retry:          options.retry,
+		requestTimeout: options.requestTimeout,
+		cache:          newReadCache(options.readCacheTTL),
+		limiter:        limiter,
+		slots:          slots,
+
+		detectTaggedLocations: options.detectTaggedLocations,
 	}
 
-	if !lazy_auth {
-		if err := c.Login(); err != nil {
+	if !lazy_auth && c.apiToken == "" {
+		if err := c.Login(ctx); err != nil {
 			return nil, err
 		}
 	}
@@ -70,13 +210,14 @@ func NewClient(host, username, password string, lazy_auth, tagged_locations, ins
 
 // buildTransport clones the default transport and applies the provider's TLS
 // settings: skipping verification entirely (insecure) or trusting an additional
-// CA bundle (PEM contents or a path to a PEM file) for private-CA controllers.
-func buildTransport(insecure bool, caBundle string) (*http.Transport, error) {
+// CA bundle (PEM contents or a path to a PEM file) for private-CA controllers,
+// and presenting a client certificate for Controllers behind mutual TLS.
+func buildTransport(insecure bool, caBundle string, options clientOptions) (*http.Transport, error) {
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	tlsConfig := &tls.Config{InsecureSkipVerify: insecure}
 
 	if caBundle != "" {
-		pem, err := caBundlePEM(caBundle)
+		pem, err := readPEM(caBundle)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read ca_bundle: %w", err)
 		}
@@ -87,21 +228,81 @@ func buildTransport(insecure bool, caBundle string) (*http.Transport, error) {
 		tlsConfig.RootCAs = pool
 	}
 
+	if options.clientCertificate != "" || options.clientKey != "" {
+		if options.clientCertificate == "" || options.clientKey == "" {
+			return nil, fmt.Errorf("client_certificate and client_key must be set together")
+		}
+		certPEM, err := readPEM(options.clientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_certificate: %w", err)
+		}
+		keyPEM, err := readPEM(options.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_key: %w", err)
+		}
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client_certificate or client_key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if options.proxyURL != "" || len(options.noProxy) > 0 {
+		proxy, err := buildProxy(options.proxyURL, options.noProxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxy
+	}
+
 	transport.TLSClientConfig = tlsConfig
 	return transport, nil
 }
 
-// caBundlePEM resolves the ca_bundle attribute, which may be inline PEM or a
-// path to a PEM file on disk.
-func caBundlePEM(caBundle string) ([]byte, error) {
-	if strings.Contains(caBundle, "-----BEGIN") {
-		return []byte(caBundle), nil
+// buildProxy returns the transport's Proxy function for proxy_url and no_proxy,
+// falling back to the proxy environment variables for whatever is unset.
+func buildProxy(proxyURL string, noProxy []string) (func(*http.Request) (*url.URL, error), error) {
+	config := httpproxy.FromEnvironment()
+
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("proxy_url must use the http, https or socks5 scheme, not %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("proxy_url %q has no host", u.Redacted())
+		}
+		config.HTTPProxy = proxyURL
+		config.HTTPSProxy = proxyURL
+	}
+	if len(noProxy) > 0 {
+		config.NoProxy = strings.Join(noProxy, ",")
+	}
+
+	proxyFunc := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// readPEM resolves a PEM-valued attribute such as ca_bundle, which may be
+// inline PEM or a path to a PEM file on disk.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
 	}
-	return os.ReadFile(caBundle)
+	return os.ReadFile(value)
 }
 
-// Check that the client has a login cookie, and if not, authenticate
-func (c *Client) ensureAuth(req *http.Request) error {
+// Check that the client has a login cookie for the endpoint req is sent to,
+// and if not, authenticate. The returned epoch identifies the session the
+// request is about to be sent with.
+func (c *Client) ensureAuth(req *http.Request, endpoint string) (uint64, error) {
 	// Wrapped in a mutex lock to ensure that we don’t spam auth
 	// requests in the event of parallel resources being checked.
 	c.authCheck.Lock()
@@ -109,46 +310,264 @@ func (c *Client) ensureAuth(req *http.Request) error {
 
 	if len(c.HTTPClient.Jar.Cookies(req.URL)) == 0 {
 		// Without any cookies for this URL, login first:
-		if err := c.Login(); err != nil {
-			return err
+		if err := c.login(req.Context(), endpoint); err != nil {
+			return c.authEpochs[endpoint], err
 		}
+		c.bumpAuthEpoch(endpoint)
+	}
+
+	return c.authEpochs[endpoint], nil
+}
+
+// reauthenticate logs in again after the Controller rejected a request sent
+// with the session from epoch. When another request has already replaced that
+// session in the meantime, the new session is reused instead of logging in a
+// second time.
+func (c *Client) reauthenticate(ctx context.Context, endpoint string, epoch uint64) error {
+	c.authCheck.Lock()
+	defer c.authCheck.Unlock()
+
+	if c.authEpochs[endpoint] != epoch {
+		return nil
 	}
 
+	if err := c.login(ctx, endpoint); err != nil {
+		return err
+	}
+	c.bumpAuthEpoch(endpoint)
+
 	return nil
 }
 
-func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	// Pre-flight check to ensure that login cookies are present.
-	if err := c.ensureAuth(req); err != nil {
-		return nil, err
+// bumpAuthEpoch records a new session for endpoint. The caller holds
+// authCheck.
+func (c *Client) bumpAuthEpoch(endpoint string) {
+	if c.authEpochs == nil {
+		c.authEpochs = map[string]uint64{}
 	}
+	c.authEpochs[endpoint]++
+}
 
-	if req.Method == http.MethodPost {
-		req.Header.Add("Content-Type", "application/json")
+// isAuthFailure reports whether the Controller refused the request because
+// the session is missing, expired or revoked.
+func isAuthFailure(status int) bool {
+	return status == http.StatusUnauthorized || status == http.StatusForbidden
+}
+
+// rewindRequest returns a copy of req that can be sent again: the body is
+// re-read from GetBody and the cookies attached by the previous attempt are
+// dropped so that the jar's current session is used instead.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	replay := req.Clone(req.Context())
+	replay.Header.Del("Cookie")
+
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, fmt.Errorf("cannot replay %s %s: request body is not rewindable", req.Method, req.URL.Path)
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		replay.Body = body
 	}
 
-	res, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
+	return replay, nil
+}
+
+// send performs a single round trip and reads the whole response body, giving
+// up once the attempt exceeds the request timeout. It first waits for the
+// client's rate and concurrency limits. The request and its outcome are logged
+// to the client's tflog subsystem.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	parent := req.Context()
+	ctx := c.logContext(parent)
+	fields := requestLogFields(req)
+
+	if err := c.acquire(parent); err != nil {
+		return nil, nil, err
 	}
-	defer res.Body.Close()
+	defer c.release()
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending request to the Controller", fields)
+
+	timeout := c.attemptTimeout(parent)
+	if timeout > 0 {
+		attemptCtx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		req = req.WithContext(attemptCtx)
 	}
 
-	if res.StatusCode < 200 || res.StatusCode >= 400 {
-		ct := res.Header.Get("Content-Type")
-		snippet := string(body)
-		if len(snippet) > 4096 {
-			snippet = snippet[:4096] + "…[truncated]"
+	httpClient := c.HTTPClient
+	if jar, ok := parent.Value(cookieJarKey{}).(http.CookieJar); ok {
+		sessionClient := *c.HTTPClient
+		sessionClient.Jar = jar
+		httpClient = &sessionClient
+	}
+
+	start := time.Now()
+	res, err := httpClient.Do(req)
+	if err == nil {
+		defer res.Body.Close()
+
+		var body []byte
+		if body, err = io.ReadAll(res.Body); err == nil {
+			tflog.SubsystemDebug(ctx, logSubsystem, "Received response from the Controller", map[string]interface{}{
+				"method":      req.Method,
+				"path":        fields["path"],
+				"status":      res.StatusCode,
+				"duration_ms": time.Since(start).Milliseconds(),
+				"headers":     redactHeaders(res.Header),
+				"body":        redactBody(body),
+			})
+			return res, body, nil
 		}
-		return nil, fmt.Errorf("HTTP %d %s (%s): %s",
-			res.StatusCode, http.StatusText(res.StatusCode), ct, strings.TrimSpace(snippet))
 	}
 
-	return body, nil
+	if parent.Err() == nil && errors.Is(req.Context().Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%s %s: no response within %s: %w", req.Method, req.URL.Path, timeout, err)
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Controller request failed", map[string]interface{}{
+		"method":      req.Method,
+		"path":        fields["path"],
+		"duration_ms": time.Since(start).Milliseconds(),
+		"error":       err.Error(),
+	})
+	return nil, nil, err
+}
+
+type minRequestTimeoutKey struct{}
+
+// withMinRequestTimeout raises the per-attempt timeout to at least d for the
+// requests made with the returned context. It is meant for endpoints that do
+// slow work on the Controller before answering.
+func withMinRequestTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, minRequestTimeoutKey{}, d)
+}
+
+// attemptTimeout returns the timeout for one attempt at a request made with
+// ctx: the client's request timeout, raised by withMinRequestTimeout.
+func (c *Client) attemptTimeout(ctx context.Context) time.Duration {
+	timeout := c.requestTimeout
+	if floor, ok := ctx.Value(minRequestTimeoutKey{}).(time.Duration); ok && timeout > 0 && floor > timeout {
+		timeout = floor
+	}
+	return timeout
+}
+
+// doRequest sends req and returns the response body. GETs go through the read
+// cache when the client has one; any other request invalidates it, whether or
+// not it succeeded, since a failed write may still have been applied.
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	if c.cache == nil {
+		return c.execute(req)
+	}
+	if req.Method != http.MethodGet {
+		defer c.cache.invalidate()
+		return c.execute(req)
+	}
+	return c.cache.get(req, c.isCacheable(req), c.execute)
+}
+
+// isCacheable reports whether req reads one of the cachedPaths.
+func (c *Client) isCacheable(req *http.Request) bool {
+	for _, path := range cachedPaths {
+		if req.URL.String() == c.getHostURL(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// execute sends req, authenticating first, re-authenticating once per endpoint
+// when the session is rejected, failing over between Controller endpoints and
+// retrying transient failures.
+func (c *Client) execute(req *http.Request) ([]byte, error) {
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	}
+	if req.Method == http.MethodPost {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	primaryURL := req.URL
+	endpoints := c.endpointOrder()
+	next := 0
+	reauthenticated := map[string]bool{}
+	for attempt := 0; ; {
+		endpoint := endpoints[next]
+		target, err := c.endpointURL(primaryURL, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		req.URL, req.Host = target, target.Host
+
+		var epoch uint64
+		if c.apiToken == "" {
+			// Pre-flight check to ensure that login cookies are present.
+			if epoch, err = c.ensureAuth(req, endpoint); err != nil {
+				if next+1 < len(endpoints) && isDialError(err) {
+					c.logFailover(req, endpoint, nil, err)
+					next++
+					continue
+				}
+				return nil, err
+			}
+		}
+
+		res, body, err := c.send(req)
+
+		if err == nil && isAuthFailure(res.StatusCode) && !reauthenticated[endpoint] && c.apiToken == "" {
+			// The Controller expired or revoked the session since it was
+			// established (common during long applies): log in again and
+			// replay the request once with the fresh session.
+			reauthenticated[endpoint] = true
+			tflog.SubsystemDebug(c.logContext(req.Context()), logSubsystem, "Controller rejected the session, logging in again", map[string]interface{}{
+				"endpoint": endpoint,
+				"status":   res.StatusCode,
+			})
+			if err := c.reauthenticate(req.Context(), endpoint, epoch); err != nil {
+				return nil, fmt.Errorf("session rejected with HTTP %d and re-authentication failed: %w", res.StatusCode, err)
+			}
+		} else if next+1 < len(endpoints) && shouldFailover(req, res, err) {
+			c.logFailover(req, endpoint, res, err)
+			next++
+		} else if attempt < c.retry.MaxRetries && isIdempotent(req) && isTransient(req, res, err) {
+			delay := c.retry.delay(attempt, res)
+			fields := map[string]interface{}{
+				"method":   req.Method,
+				"path":     req.URL.RequestURI(),
+				"attempt":  attempt + 1,
+				"delay_ms": delay.Milliseconds(),
+			}
+			if err != nil {
+				fields["error"] = err.Error()
+			} else {
+				fields["status"] = res.StatusCode
+			}
+			tflog.SubsystemDebug(c.logContext(req.Context()), logSubsystem, "Retrying Controller request", fields)
+			if err := sleep(req.Context(), delay); err != nil {
+				return nil, err
+			}
+			attempt++
+			// Every endpoint failed this round: start the next one from
+			// whichever endpoint is preferred by then.
+			endpoints, next = c.endpointOrder(), 0
+		} else {
+			if err != nil {
+				return nil, err
+			}
+			if res.StatusCode < 200 || res.StatusCode >= 400 {
+				return nil, newAPIError(req, res, body)
+			}
+			c.markHealthy(endpoint)
+			return body, nil
+		}
+
+		if req, err = rewindRequest(req); err != nil {
+			return nil, err
+		}
+	}
 }
 
 func (c *Client) getHostURL(path string) string {
@@ -162,8 +581,13 @@ func (c *Client) getHostURL(path string) string {
 // does not return a 404, unmarshaling the response into out (when non-nil).
 // Controller versions disagree on whether a nested index route carries a
 // trailing slash, so callers pass both forms and the client uses whichever the
-// Controller serves.
-func (c *Client) doJSONWithFallback(method string, body []byte, out any, paths ...string) error {
+// Controller serves. The form that worked is tried first from then on.
+func (c *Client) doJSONWithFallback(ctx context.Context, method string, body []byte, out any, paths ...string) error {
+	key := strings.Join(paths, " ")
+	if route, ok := c.routes.Load(key); ok {
+		paths = preferRoute(paths, route.(string))
+	}
+
 	var lastErr error
 	for _, path := range paths {
 		var reader io.Reader
@@ -171,7 +595,7 @@ func (c *Client) doJSONWithFallback(method string, body []byte, out any, paths .
 			reader = bytes.NewReader(body)
 		}
 
-		req, err := http.NewRequest(method, c.getHostURL(path), reader)
+		req, err := http.NewRequestWithContext(ctx, method, c.getHostURL(path), reader)
 		if err != nil {
 			return err
 		}
@@ -179,11 +603,14 @@ func (c *Client) doJSONWithFallback(method string, body []byte, out any, paths .
 		respBody, err := c.doRequest(req)
 		if err != nil {
 			lastErr = err
-			if strings.Contains(err.Error(), "HTTP 404") {
+			if errors.Is(err, ErrNotFound) {
 				continue
 			}
 			return err
 		}
+		if len(paths) > 1 {
+			c.routes.Store(key, path)
+		}
 
 		if out != nil {
 			return json.Unmarshal(respBody, out)
@@ -193,7 +620,19 @@ func (c *Client) doJSONWithFallback(method string, body []byte, out any, paths .
 	return lastErr
 }
 
+// preferRoute returns paths with route moved to the front.
+func preferRoute(paths []string, route string) []string {
+	ordered := make([]string, 0, len(paths))
+	ordered = append(ordered, route)
+	for _, path := range paths {
+		if path != route {
+			ordered = append(ordered, path)
+		}
+	}
+	return ordered
+}
+
 // getListJSON GETs the first of the supplied paths that does not 404.
-func (c *Client) getListJSON(out any, paths ...string) error {
-	return c.doJSONWithFallback(http.MethodGet, nil, out, paths...)
+func (c *Client) getListJSON(ctx context.Context, out any, paths ...string) error {
+	return c.doJSONWithFallback(ctx, http.MethodGet, nil, out, paths...)
 }
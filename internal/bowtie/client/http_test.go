@@ -1,8 +1,16 @@
 package client
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestClient_getHostURL(t *testing.T) {
@@ -94,3 +102,259 @@ func TestClient_getHostURL(t *testing.T) {
 		})
 	}
 }
+
+// sessionServer emulates a Controller whose sessions can be revoked: only the
+// cookie issued by the most recent login is accepted.
+type sessionServer struct {
+	mu      sync.Mutex
+	logins  int
+	current string
+}
+
+func (s *sessionServer) login(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logins++
+	s.current = fmt.Sprintf("session-%d", s.logins)
+	http.SetCookie(w, &http.Cookie{Name: "session", Value: s.current, Path: "/"})
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *sessionServer) authorized(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cookie, err := r.Cookie("session")
+	return err == nil && cookie.Value == s.current
+}
+
+func TestDoRequestReauthenticatesExpiredSession(t *testing.T) {
+	sessions := &sessionServer{}
+	var replayedBody string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/-net/api/v0/user/login":
+			sessions.login(w)
+		case "/-net/api/v0/group/upsert":
+			if !sessions.authorized(r) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			replayedBody = string(body)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(body)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	// The seeded "test" cookie is not a session the server recognizes, as if
+	// the Controller had expired it mid-apply.
+	c := newTestClient(t, ts)
+
+	if _, err := c.UpsertGroup(context.Background(), "g-1", "Engineering"); err != nil {
+		t.Fatalf("UpsertGroup: %v", err)
+	}
+	if sessions.logins != 1 {
+		t.Errorf("logins = %d, want 1", sessions.logins)
+	}
+	if !strings.Contains(replayedBody, `"Engineering"`) {
+		t.Errorf("replayed request lost its body: %q", replayedBody)
+	}
+}
+
+func TestDoRequestReplaysOnlyOnce(t *testing.T) {
+	sessions := &sessionServer{}
+	var attempts int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/-net/api/v0/user/login":
+			sessions.login(w)
+		default:
+			// The account lacks permission: every attempt is refused.
+			attempts++
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer ts.Close()
+
+	_, err := newTestClient(t, ts).GetGroups(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Fatalf("expected the HTTP 403 to surface, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want the original request plus one replay", attempts)
+	}
+	if sessions.logins != 1 {
+		t.Errorf("logins = %d, want 1", sessions.logins)
+	}
+}
+
+func TestDoRequestSharesReauthenticationAcrossConcurrentRequests(t *testing.T) {
+	sessions := &sessionServer{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/-net/api/v0/user/login":
+			sessions.login(w)
+		default:
+			if !sessions.authorized(r) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetGroups(context.Background())
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetGroups: %v", err)
+		}
+	}
+	if sessions.logins != 1 {
+		t.Errorf("logins = %d, want a single shared re-authentication", sessions.logins)
+	}
+}
+
+func TestDoRequestUsesAPITokenInsteadOfLogin(t *testing.T) {
+	var authorization []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/-net/api/v0/user/login" {
+			t.Error("a token-authenticated client must not log in")
+		}
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		if len(authorization) > 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(context.Background(), ts.URL, "", "", false, true, false, "", WithAPIToken("s3cr3t"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := c.GetGroups(context.Background()); err != nil {
+		t.Fatalf("GetGroups: %v", err)
+	}
+	// A rejected token is final: there is no session to refresh.
+	if _, err := c.GetGroups(context.Background()); err == nil || !strings.Contains(err.Error(), "HTTP 401") {
+		t.Fatalf("expected the HTTP 401 to surface, got %v", err)
+	}
+
+	if len(authorization) != 2 {
+		t.Fatalf("requests = %d, want 2", len(authorization))
+	}
+	for _, got := range authorization {
+		if got != "Bearer s3cr3t" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer s3cr3t")
+		}
+	}
+}
+
+func TestDoRequestAbortsWhenContextIsCancelled(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := newTestClient(t, ts).GetGroups(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("GetGroups returned after %s, want it to abort promptly", elapsed)
+	}
+}
+
+func TestDoRequestRetriesAttemptsThatTimeOut(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		first := attempts == 1
+		mu.Unlock()
+		if first {
+			// Stall the first attempt past the request timeout.
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	c := newTestClient(t, ts)
+	c.retry = fastRetries
+	c.requestTimeout = 20 * time.Millisecond
+
+	if _, err := c.GetGroups(context.Background()); err != nil {
+		t.Fatalf("GetGroups: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+
+	c.retry = RetryPolicy{}
+	attempts = 0
+	_, err := c.GetGroups(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no response within 20ms") {
+		t.Fatalf("expected the attempt timeout to surface, got %v", err)
+	}
+}
+
+func TestAttemptTimeoutHonorsMinimum(t *testing.T) {
+	c := &Client{requestTimeout: 10 * time.Second}
+	ctx := context.Background()
+
+	if got := c.attemptTimeout(ctx); got != 10*time.Second {
+		t.Errorf("attemptTimeout = %s, want 10s", got)
+	}
+	if got := c.attemptTimeout(withMinRequestTimeout(ctx, 30*time.Second)); got != 30*time.Second {
+		t.Errorf("attemptTimeout with a higher minimum = %s, want 30s", got)
+	}
+	if got := c.attemptTimeout(withMinRequestTimeout(ctx, time.Second)); got != 10*time.Second {
+		t.Errorf("attemptTimeout with a lower minimum = %s, want 10s", got)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// WithRateLimit caps how many requests per second the client sends to the
+// Controller. Retries, replays and logins count against the same budget. A
+// value of zero or less leaves the rate unlimited.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(o *clientOptions) {
+		o.requestsPerSecond = requestsPerSecond
+	}
+}
+
+// WithMaxConcurrentRequests caps how many requests the client has in flight
+// at once. A value of zero or less leaves concurrency unlimited.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(o *clientOptions) {
+		o.maxConcurrentRequests = n
+	}
+}
+
+// newLimits builds the rate limiter and concurrency semaphore for the options,
+// either of which is nil when its setting is unlimited. The limiter's burst is
+// one so that parallel resources are spread evenly instead of landing on the
+// Controller together at the start of every second.
+func newLimits(options clientOptions) (*rate.Limiter, chan struct{}) {
+	var limiter *rate.Limiter
+	if options.requestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(options.requestsPerSecond), 1)
+	}
+
+	var slots chan struct{}
+	if options.maxConcurrentRequests > 0 {
+		slots = make(chan struct{}, options.maxConcurrentRequests)
+	}
+
+	return limiter, slots
+}
+
+// acquire waits until the client may send another request: for a free
+// concurrency slot, then for the rate limiter. On success the caller must call
+// release once the response has been read.
+func (c *Client) acquire(ctx context.Context) error {
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			c.release()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// release frees the concurrency slot taken by acquire.
+func (c *Client) release() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitSpacesRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.limiter, c.slots = newLimits(clientOptions{requestsPerSecond: 20})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetGroups(context.Background()); err != nil {
+			t.Fatalf("GetGroups: %v", err)
+		}
+	}
+	// The first request goes out at once; the next two wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 requests at 20/s took %s, want at least 100ms", elapsed)
+	}
+}
+
+func TestMaxConcurrentRequestsCapsRequestsInFlight(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, peak int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.limiter, c.slots = newLimits(clientOptions{maxConcurrentRequests: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := c.UpsertGroup(context.Background(), fmt.Sprintf("g-%d", i), "Engineering"); err != nil {
+				t.Errorf("UpsertGroup: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if peak != 2 {
+		t.Errorf("peak requests in flight = %d, want 2", peak)
+	}
+}
+
+func TestAcquireGivesUpWhenContextIsDone(t *testing.T) {
+	c := &Client{}
+	c.limiter, c.slots = newLimits(clientOptions{maxConcurrentRequests: 1})
+
+	if err := c.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer c.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.acquire(ctx); err == nil {
+		t.Fatal("expected acquire to fail while every slot is taken")
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -27,7 +28,7 @@ func TestGetDeviceGroupsFallsBackWhenSlashed404s(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	groups, err := newTestClient(t, ts).GetDeviceGroups()
+	groups, err := newTestClient(t, ts).GetDeviceGroups(context.Background())
 	if err != nil {
 		t.Fatalf("GetDeviceGroups: %v", err)
 	}
@@ -48,7 +49,7 @@ func TestGetCollectionsUsesSlashedFormWhenItWorks(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	if _, err := newTestClient(t, ts).GetCollections(); err != nil {
+	if _, err := newTestClient(t, ts).GetCollections(context.Background()); err != nil {
 		t.Fatalf("GetCollections: %v", err)
 	}
 	// The slashed form works, so the bare form must never be requested.
@@ -63,7 +64,38 @@ func TestGetListJSONPropagatesNon404Errors(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	if _, err := newTestClient(t, ts).GetDeviceGroups(); err == nil {
+	if _, err := newTestClient(t, ts).GetDeviceGroups(context.Background()); err == nil {
 		t.Fatal("expected a 500 to propagate as an error")
 	}
 }
+
+func TestFallbackRemembersTheRouteThatWorked(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/-net/api/v0/device_group/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetDeviceGroups(context.Background()); err != nil {
+			t.Fatalf("GetDeviceGroups: %v", err)
+		}
+	}
+	// The second call goes straight to the bare form found by the first.
+	want := []string{"/-net/api/v0/device_group/", "/-net/api/v0/device_group", "/-net/api/v0/device_group"}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("paths = %v, want %v", paths, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem carrying the client's request and
+// response logs. Its level follows TF_LOG_PROVIDER_BOWTIE_CLIENT when set and
+// the provider's log level otherwise.
+const logSubsystem = "client"
+
+// maxLogBody bounds how much of a request or response body is logged.
+const maxLogBody = 64 * 1024
+
+// redacted replaces secret values in logged bodies and headers, matching the
+// mask tflog applies.
+const redacted = "***"
+
+// sensitiveHeaders are masked wherever request or response headers are logged.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveKeyWords mark a JSON member as secret when any word of its name
+// (split on underscores, dashes and the like) is one of them.
+var sensitiveKeyWords = map[string]bool{
+	"apikey":   true,
+	"cookie":   true,
+	"key":      true,
+	"passwd":   true,
+	"password": true,
+	"psk":      true,
+	"secret":   true,
+	"token":    true,
+}
+
+// logContext returns ctx with the client's logging subsystem attached. The
+// configured password and API token are masked wherever they appear, as a
+// backstop for bodies that redactBody cannot parse.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_BOWTIE", logSubsystem))
+
+	var secrets []string
+	for _, secret := range []string{c.auth.Password, c.apiToken} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, secrets...)
+	}
+
+	return ctx
+}
+
+// requestLogFields describes req for the request log entry, reading the body
+// through GetBody so that the request itself is left untouched.
+func requestLogFields(req *http.Request) map[string]interface{} {
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"path":    req.URL.RequestURI(),
+		"headers": redactHeaders(req.Header),
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(body)
+			body.Close()
+			if buf.Len() > 0 {
+				fields["body"] = redactBody(buf.Bytes())
+			}
+		}
+	}
+
+	return fields
+}
+
+// redactHeaders flattens header for logging with the credential-bearing
+// headers masked.
+func redactHeaders(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for name, values := range header {
+		out[name] = strings.Join(values, ", ")
+	}
+	for _, name := range sensitiveHeaders {
+		if _, ok := out[name]; ok {
+			out[name] = redacted
+		}
+	}
+	return out
+}
+
+// redactBody renders a request or response body for logging. JSON bodies
+// have the values of secret-looking members masked at any depth; anything
+// else is logged as is. Either way the result is truncated to maxLogBody.
+func redactBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err == nil && !decoder.More() {
+		if redactedBody, err := json.Marshal(redactValue(value)); err == nil {
+			body = redactedBody
+		}
+	}
+
+	if len(body) > maxLogBody {
+		return string(body[:maxLogBody]) + "…[truncated]"
+	}
+	return string(body)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, member := range v {
+			if isSensitiveKey(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(member)
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactValue(element)
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	words := strings.FieldsFunc(strings.ToLower(key), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if sensitiveKeyWords[word] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "login payload",
+			body: `{"email":"admin@example.com","password":"hunter2"}`,
+			want: `{"email":"admin@example.com","password":"***"}`,
+		},
+		{
+			name: "nested keys and psks",
+			body: `{"c-1":{"public_key":"abc","psk":"def","name":"east"},"list":[{"api_token":"ghi"}]}`,
+			want: `{"c-1":{"name":"east","psk":"***","public_key":"***"},"list":[{"api_token":"***"}]}`,
+		},
+		{
+			name: "keys that only resemble secrets",
+			body: `{"persistent_keepalive":25,"keyword":"x"}`,
+			want: `{"keyword":"x","persistent_keepalive":25}`,
+		},
+		{
+			name: "non-JSON body",
+			body: `Not Found`,
+			want: `Not Found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactBody() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendLogsRequestsWithSecretsMasked(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/-net/api/v0/user/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "fresh-session"})
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"g-1":{"id":"g-1","name":"Engineering"}}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.auth = AuthPayload{Username: "admin@example.com", Password: "hunter2"}
+	c.apiToken = ""
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	if err := c.Login(ctx); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if _, err := c.GetGroups(ctx); err != nil {
+		t.Fatalf("GetGroups: %v", err)
+	}
+
+	logged := output.String()
+	for _, secret := range []string{"hunter2", "fresh-session"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log output leaks %q:\n%s", secret, logged)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decode log output: %v", err)
+	}
+
+	var sawResponse bool
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+logSubsystem {
+			t.Errorf("entry logged outside the client subsystem: %v", entry)
+		}
+		if entry["@message"] == "Received response from the Controller" && entry["path"] == "/-net/api/v0/group" {
+			sawResponse = true
+			if entry["status"] != float64(http.StatusOK) || entry["method"] != http.MethodGet {
+				t.Errorf("unexpected response entry: %v", entry)
+			}
+			if !strings.Contains(entry["body"].(string), "Engineering") {
+				t.Errorf("response body missing from entry: %v", entry)
+			}
+		}
+	}
+	if !sawResponse {
+		t.Errorf("no response entry for GET /group in:\n%v", entries)
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -12,8 +13,8 @@ import (
 // back. This preserves every field the provider does not surface.
 type OrgConfig map[string]json.RawMessage
 
-func (c *Client) GetOrgConfig() (OrgConfig, error) {
-	req, err := http.NewRequest(http.MethodGet, c.getHostURL("/organization/config"), nil)
+func (c *Client) GetOrgConfig(ctx context.Context) (OrgConfig, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getHostURL("/organization/config"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -28,12 +29,12 @@ func (c *Client) GetOrgConfig() (OrgConfig, error) {
 	return cfg, nil
 }
 
-func (c *Client) UpdateOrgConfig(cfg OrgConfig) error {
+func (c *Client) UpdateOrgConfig(ctx context.Context, cfg OrgConfig) error {
 	body, err := json.Marshal(cfg)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/organization/config"), strings.NewReader(string(body)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/organization/config"), strings.NewReader(string(body)))
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -27,12 +28,12 @@ type OrgIPv6Range struct {
 	AssignAddressesFromHere bool   `json:"assign_addresses_from_here"`
 }
 
-func (c *Client) UpsertIPv4Range(r *OrgIPv4Range) (*OrgIPv4Range, error) {
+func (c *Client) UpsertIPv4Range(ctx context.Context, r *OrgIPv4Range) (*OrgIPv4Range, error) {
 	body, err := json.Marshal(r)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/organization/ipv4"), strings.NewReader(string(body)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/organization/ipv4"), strings.NewReader(string(body)))
 	if err != nil {
 		return nil, err
 	}
@@ -47,18 +48,27 @@ func (c *Client) UpsertIPv4Range(r *OrgIPv4Range) (*OrgIPv4Range, error) {
 	return &out, nil
 }
 
-func (c *Client) GetIPv4Range(id string) (*OrgIPv4Range, error) {
+// ListIPv4Ranges returns every organization IPv4 pool.
+func (c *Client) ListIPv4Ranges(ctx context.Context) ([]OrgIPv4Range, error) {
+	var out []OrgIPv4Range
+	if err := c.getListJSON(ctx, &out, "/organization/ipv4", "/organization/ipv4/"); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) GetIPv4Range(ctx context.Context, id string) (*OrgIPv4Range, error) {
 	var out OrgIPv4Range
-	if err := c.getListJSON(&out, fmt.Sprintf("/organization/ipv4/%s", id)); err != nil {
+	if err := c.getListJSON(ctx, &out, fmt.Sprintf("/organization/ipv4/%s", id)); err != nil {
 		return nil, err
 	}
 	return &out, nil
 }
 
-func (c *Client) DeleteIPv4Range(id string) error {
+func (c *Client) DeleteIPv4Range(ctx context.Context, id string) error {
 	// cascade=true so destroy succeeds even when devices hold allocations from
 	// the range; without it the Controller rejects the delete with a 400.
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/organization/ipv4/%s?cascade=true", id)), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/organization/ipv4/%s?cascade=true", id)), nil)
 	if err != nil {
 		return err
 	}
@@ -66,12 +76,12 @@ func (c *Client) DeleteIPv4Range(id string) error {
 	return err
 }
 
-func (c *Client) UpsertIPv6Range(r *OrgIPv6Range) (*OrgIPv6Range, error) {
+func (c *Client) UpsertIPv6Range(ctx context.Context, r *OrgIPv6Range) (*OrgIPv6Range, error) {
 	body, err := json.Marshal(r)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/organization/ipv6"), strings.NewReader(string(body)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/organization/ipv6"), strings.NewReader(string(body)))
 	if err != nil {
 		return nil, err
 	}
@@ -88,16 +98,16 @@ func (c *Client) UpsertIPv6Range(r *OrgIPv6Range) (*OrgIPv6Range, error) {
 
 // ListIPv6Ranges returns every organization IPv6 pool. The Controller serves
 // IPv6 reads as a list, so callers filter by ID.
-func (c *Client) ListIPv6Ranges() ([]OrgIPv6Range, error) {
+func (c *Client) ListIPv6Ranges(ctx context.Context) ([]OrgIPv6Range, error) {
 	var out []OrgIPv6Range
-	if err := c.getListJSON(&out, "/organization/ipv6", "/organization/ipv6/"); err != nil {
+	if err := c.getListJSON(ctx, &out, "/organization/ipv6", "/organization/ipv6/"); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *Client) GetIPv6Range(id string) (*OrgIPv6Range, error) {
-	ranges, err := c.ListIPv6Ranges()
+func (c *Client) GetIPv6Range(ctx context.Context, id string) (*OrgIPv6Range, error) {
+	ranges, err := c.ListIPv6Ranges(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -106,11 +116,11 @@ func (c *Client) GetIPv6Range(id string) (*OrgIPv6Range, error) {
 			return &ranges[i], nil
 		}
 	}
-	return nil, fmt.Errorf("ipv6 range not found: %s", id)
+	return nil, fmt.Errorf("ipv6 range %s: %w", id, ErrNotFound)
 }
 
-func (c *Client) DeleteIPv6Range(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/organization/ipv6/%s?cascade=true", id)), nil)
+func (c *Client) DeleteIPv6Range(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/organization/ipv6/%s?cascade=true", id)), nil)
 	if err != nil {
 		return err
 	}
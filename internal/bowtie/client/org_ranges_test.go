@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -49,7 +50,7 @@ func TestGetIPv4RangeNotFoundReturnsHTTP404(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	_, err := newTestClient(t, ts).GetIPv4Range("missing")
+	_, err := newTestClient(t, ts).GetIPv4Range(context.Background(), "missing")
 	if err == nil {
 		t.Fatal("expected GetIPv4Range to return an error")
 	}
@@ -65,7 +66,7 @@ func TestGetIPv6RangeNotFoundIsActionable(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	_, err := newTestClient(t, ts).GetIPv6Range("missing")
+	_, err := newTestClient(t, ts).GetIPv6Range(context.Background(), "missing")
 	if err == nil {
 		t.Fatal("expected GetIPv6Range to return a not-found error")
 	}
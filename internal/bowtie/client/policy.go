@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -135,13 +136,13 @@ func (p *BowtiePredicate) UnmarshalJSON(data []byte) error {
 
 // UpsertPolicy creates or replaces a policy. The server assigns an order when
 // one is not supplied, so the persisted policy is returned to the caller.
-func (c *Client) UpsertPolicy(policy BowtiePolicy) (BowtiePolicy, error) {
+func (c *Client) UpsertPolicy(ctx context.Context, policy BowtiePolicy) (BowtiePolicy, error) {
 	body, err := json.Marshal(policy)
 	if err != nil {
 		return BowtiePolicy{}, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/policy/upsert_policy"), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/policy/upsert_policy"), bytes.NewBuffer(body))
 	if err != nil {
 		return BowtiePolicy{}, err
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -55,7 +56,7 @@ func TestUpsertPolicyRoundTrip(t *testing.T) {
 
 	c := newTestClient(t, ts)
 
-	saved, err := c.UpsertPolicy(BowtiePolicy{
+	saved, err := c.UpsertPolicy(context.Background(), BowtiePolicy{
 		ID:     "p-1",
 		Source: BowtiePolicySource{ID: "s-1", Predicate: BowtiePredicate{InUserGroup: "ug-1"}},
 		Dest:   "rg-1",
@@ -106,7 +107,7 @@ func TestGetPolicyParsesDocument(t *testing.T) {
 
 	c := newTestClient(t, ts)
 
-	policy, err := c.GetPolicy("p-1")
+	policy, err := c.GetPolicy(context.Background(), "p-1")
 	if err != nil {
 		t.Fatalf("GetPolicy: %v", err)
 	}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -42,10 +43,12 @@ func (rl *BowtieResourceLocation) UnmarshalJSON(data []byte) error {
 	// First, try unmarshaling into BowtieResourceLocationTagged
 	//
 	// Unmarshal ordering is important here since the untagged version
-	// has all-optional fields.
-	if err := json.Unmarshal(data, &rl.Tagged); err == nil {
+	// has all-optional fields. An untagged location also decodes into the
+	// tagged type, only without a type, so that is not a match.
+	if err := json.Unmarshal(data, &rl.Tagged); err == nil && rl.Tagged != nil && rl.Tagged.Type != "" {
 		return nil
 	}
+	rl.Tagged = nil
 
 	// Then, try unmarshaling into BowtieResourceLocationUntagged
 	if err := json.Unmarshal(data, &rl.Untagged); err == nil {
@@ -86,7 +89,7 @@ type BowtieResourcePortCollection struct {
 	Ports []int64 `json:"ports,omitempty"`
 }
 
-func (c *Client) UpsertResource(id, name, protocol string, location BowtieResourceLocation, portRange, portCollection []int64) (BowtieResource, error) {
+func (c *Client) UpsertResource(ctx context.Context, id, name, protocol string, location BowtieResourceLocation, portRange, portCollection []int64) (BowtieResource, error) {
 	payload := BowtieResource{
 		ID:       id,
 		Name:     name,
@@ -108,7 +111,7 @@ func (c *Client) UpsertResource(id, name, protocol string, location BowtieResour
 		return BowtieResource{}, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/policy/upsert_resource"), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/policy/upsert_resource"), bytes.NewBuffer(body))
 	if err != nil {
 		return BowtieResource{}, err
 	}
@@ -127,8 +130,8 @@ func (c *Client) UpsertResource(id, name, protocol string, location BowtieResour
 	return resource, nil
 }
 
-func (c *Client) GetPoliciesAndResources() (*PoliciesEndpointResponse, error) {
-	req, err := http.NewRequest(http.MethodGet, c.getHostURL("/policy"), nil)
+func (c *Client) GetPoliciesAndResources(ctx context.Context) (*PoliciesEndpointResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getHostURL("/policy"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -143,22 +146,22 @@ func (c *Client) GetPoliciesAndResources() (*PoliciesEndpointResponse, error) {
 	return policy, err
 }
 
-func (c *Client) GetPolicy(id string) (BowtiePolicy, error) {
-	policyInfo, err := c.GetPoliciesAndResources()
+func (c *Client) GetPolicy(ctx context.Context, id string) (BowtiePolicy, error) {
+	policyInfo, err := c.GetPoliciesAndResources(ctx)
 	if err != nil {
 		return BowtiePolicy{}, err
 	}
 
 	policy, ok := policyInfo.Policies[id]
 	if !ok {
-		return BowtiePolicy{}, fmt.Errorf("policy not found")
+		return BowtiePolicy{}, fmt.Errorf("policy %s: %w", id, ErrNotFound)
 	}
 
 	return policy, nil
 }
 
-func (c *Client) GetResourceGroups() (map[string]BowtieResourceGroup, error) {
-	rp, err := c.GetPoliciesAndResources()
+func (c *Client) GetResourceGroups(ctx context.Context) (map[string]BowtieResourceGroup, error) {
+	rp, err := c.GetPoliciesAndResources(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -166,8 +169,8 @@ func (c *Client) GetResourceGroups() (map[string]BowtieResourceGroup, error) {
 	return rp.ResourceGroups, nil
 }
 
-func (c *Client) GetResources() (map[string]BowtieResource, error) {
-	rp, err := c.GetPoliciesAndResources()
+func (c *Client) GetResources(ctx context.Context) (map[string]BowtieResource, error) {
+	rp, err := c.GetPoliciesAndResources(ctx)
 	if err != nil {
 		return make(map[string]BowtieResource), err
 	}
@@ -175,8 +178,8 @@ func (c *Client) GetResources() (map[string]BowtieResource, error) {
 	return rp.Resources, nil
 }
 
-func (c *Client) DeletePolicy(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/policy/%s", id)), nil)
+func (c *Client) DeletePolicy(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/policy/%s", id)), nil)
 	if err != nil {
 		return err
 	}
@@ -185,8 +188,8 @@ func (c *Client) DeletePolicy(id string) error {
 	return err
 }
 
-func (c *Client) DeleteResource(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/policy/resource/%s", id)), nil)
+func (c *Client) DeleteResource(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/policy/resource/%s", id)), nil)
 	if err != nil {
 		return err
 	}
@@ -195,7 +198,7 @@ func (c *Client) DeleteResource(id string) error {
 	return err
 }
 
-func (c *Client) UpsertResourceGroup(id, name string, resources, resource_groups []string) error {
+func (c *Client) UpsertResourceGroup(ctx context.Context, id, name string, resources, resource_groups []string) error {
 	payload := BowtieResourceGroup{
 		ID:        id,
 		Name:      name,
@@ -208,7 +211,7 @@ func (c *Client) UpsertResourceGroup(id, name string, resources, resource_groups
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/policy/upsert_resource_group"), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/policy/upsert_resource_group"), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -217,8 +220,8 @@ func (c *Client) UpsertResourceGroup(id, name string, resources, resource_groups
 	return err
 }
 
-func (c *Client) DeleteResourceGroup(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/policy/resource_group/%s", id)), nil)
+func (c *Client) DeleteResourceGroup(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/policy/resource_group/%s", id)), nil)
 	if err != nil {
 		return err
 	}
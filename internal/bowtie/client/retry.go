@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how the client retries requests that failed for a
+// transient reason: a network error or a 429, 502, 503 or 504 response. Only
+// idempotent requests are retried (see isIdempotent).
+type RetryPolicy struct {
+	// MaxRetries is the number of attempts made after the first one fails.
+	// Zero disables retries.
+	MaxRetries int
+	// MinWait is the base delay before the first retry; each further retry
+	// doubles it.
+	MinWait time.Duration
+	// MaxWait caps every delay, including one requested by the Controller
+	// through a Retry-After header.
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy rides out the brief 502/503 window of a Controller
+// restarting for an upgrade without stalling an apply for long.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 4,
+	MinWait:    500 * time.Millisecond,
+	MaxWait:    30 * time.Second,
+}
+
+// isIdempotent reports whether req can safely be sent more than once. Besides
+// GET and DELETE, the upsert_* POSTs qualify: their payloads are keyed by IDs
+// generated on the client, so replaying one converges on the same object.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return strings.HasPrefix(path.Base(req.URL.Path), "upsert")
+	default:
+		return false
+	}
+}
+
+// isTransient reports whether the outcome of a round trip is worth retrying.
+// A cancelled or expired request context is final, whatever the error.
+func isTransient(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// delay returns how long to wait before retry number attempt (starting at
+// zero). A Retry-After header on res takes precedence over the exponential
+// backoff; either way the result never exceeds MaxWait.
+func (p RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return p.capped(wait)
+		}
+	}
+
+	backoff := p.MinWait
+	for i := 0; i < attempt && backoff < p.MaxWait; i++ {
+		backoff *= 2
+	}
+	backoff = p.capped(backoff)
+	if backoff <= 0 {
+		return 0
+	}
+
+	// Keep half of the backoff and randomize the rest so that parallel
+	// resources rejected together do not retry in lockstep.
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+func (p RetryPolicy) capped(wait time.Duration) time.Duration {
+	if p.MaxWait > 0 && wait > p.MaxWait {
+		return p.MaxWait
+	}
+	return wait
+}
+
+// retryAfter parses a Retry-After header, given either as a number of seconds
+// or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		wait := time.Until(when)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
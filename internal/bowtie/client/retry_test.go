@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var fastRetries = RetryPolicy{MaxRetries: 3, MinWait: time.Millisecond, MaxWait: 5 * time.Millisecond}
+
+func TestDoRequestRetriesTransientErrors(t *testing.T) {
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.retry = fastRetries
+
+	if _, err := c.GetGroups(context.Background()); err != nil {
+		t.Fatalf("GetGroups: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestDoRequestGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.retry = fastRetries
+
+	if err := c.DeleteGroup(context.Background(), "g-1"); err == nil || !strings.Contains(err.Error(), "HTTP 503") {
+		t.Fatalf("expected the final HTTP 503 to surface, got %v", err)
+	}
+	if attempts != fastRetries.MaxRetries+1 {
+		t.Errorf("attempts = %d, want %d", attempts, fastRetries.MaxRetries+1)
+	}
+}
+
+func TestDoRequestDoesNotRetryNonIdempotentPOST(t *testing.T) {
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.retry = fastRetries
+
+	if _, err := c.AddUserToGroup(context.Background(), "g-1", []string{"u-1"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want a single attempt for a non-idempotent POST", attempts)
+	}
+}
+
+func TestDoRequestRetriesUpsertWithBody(t *testing.T) {
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.retry = fastRetries
+
+	if _, err := c.UpsertGroup(context.Background(), "g-1", "Engineering"); err != nil {
+		t.Fatalf("UpsertGroup: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("expected the retried upsert to resend the same body, got %q", bodies)
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodGet, "/-net/api/v0/policy", true},
+		{http.MethodDelete, "/-net/api/v0/group/g-1", true},
+		{http.MethodPost, "/-net/api/v0/policy/upsert_policy", true},
+		{http.MethodPost, "/-net/api/v0/user/upsert", true},
+		{http.MethodPost, "/-net/api/v0/group/addusers", false},
+		{http.MethodPost, "/-net/api/v0/organization/controller", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if got := isIdempotent(req); got != tt.want {
+			t.Errorf("isIdempotent(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 10, MinWait: time.Second, MaxWait: 8 * time.Second}
+
+	for attempt, ceiling := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second} {
+		got := policy.delay(attempt, nil)
+		if got < ceiling/2 || got > ceiling {
+			t.Errorf("delay(%d) = %v, want within [%v, %v]", attempt, got, ceiling/2, ceiling)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if got := policy.delay(0, res); got != 3*time.Second {
+		t.Errorf("delay with Retry-After: 3 = %v, want 3s", got)
+	}
+
+	res.Header.Set("Retry-After", "120")
+	if got := policy.delay(0, res); got != policy.MaxWait {
+		t.Errorf("delay with Retry-After: 120 = %v, want it capped at %v", got, policy.MaxWait)
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -41,29 +42,29 @@ type BowtieApplyStrategy struct {
 	Value *int   `json:"value,omitempty"`
 }
 
-func (c *Client) GetRouteExclusions() (map[string]BowtieRouteExclusion, error) {
+func (c *Client) GetRouteExclusions(ctx context.Context) (map[string]BowtieRouteExclusion, error) {
 	exclusions := map[string]BowtieRouteExclusion{}
-	if err := c.getListJSON(&exclusions, "/route_exclusion/", "/route_exclusion"); err != nil {
+	if err := c.getListJSON(ctx, &exclusions, "/route_exclusion/", "/route_exclusion"); err != nil {
 		return nil, err
 	}
 	return exclusions, nil
 }
 
-func (c *Client) UpsertRouteExclusion(exclusion BowtieRouteExclusion) (BowtieRouteExclusion, error) {
+func (c *Client) UpsertRouteExclusion(ctx context.Context, exclusion BowtieRouteExclusion) (BowtieRouteExclusion, error) {
 	body, err := json.Marshal(exclusion)
 	if err != nil {
 		return BowtieRouteExclusion{}, err
 	}
 
 	var saved BowtieRouteExclusion
-	if err := c.doJSONWithFallback(http.MethodPost, body, &saved, "/route_exclusion/", "/route_exclusion"); err != nil {
+	if err := c.doJSONWithFallback(ctx, http.MethodPost, body, &saved, "/route_exclusion/", "/route_exclusion"); err != nil {
 		return BowtieRouteExclusion{}, err
 	}
 	return saved, nil
 }
 
-func (c *Client) DeleteRouteExclusion(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/route_exclusion/%s", id)), nil)
+func (c *Client) DeleteRouteExclusion(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/route_exclusion/%s", id)), nil)
 	if err != nil {
 		return err
 	}
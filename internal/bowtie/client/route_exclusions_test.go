@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -89,7 +90,7 @@ func TestUpsertRouteExclusionPathAndResponse(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	saved, err := newTestClient(t, ts).UpsertRouteExclusion(BowtieRouteExclusion{
+	saved, err := newTestClient(t, ts).UpsertRouteExclusion(context.Background(), BowtieRouteExclusion{
 		ID:            "re-1",
 		Name:          "x",
 		CollectionID:  "c",
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+)
+
+// Session is a Controller session opened by OpenSession, separate from the
+// one the client itself uses, for tools that call the Controller API
+// directly.
+type Session struct {
+	// Endpoint is the Controller endpoint the session belongs to.
+	Endpoint string
+	// Cookies holds the session cookies, set when the session was opened by
+	// logging in.
+	Cookies []*http.Cookie
+	// Token is the API token the client authenticates with, set instead of
+	// Cookies for clients created WithAPIToken.
+	Token string
+}
+
+// Headers returns the HTTP headers that authenticate a request as the
+// session.
+func (s *Session) Headers() map[string]string {
+	if s.Token != "" {
+		return map[string]string{"Authorization": "Bearer " + s.Token}
+	}
+	return map[string]string{"Cookie": s.CookieHeader()}
+}
+
+// CookieHeader returns the session cookies as the value of a Cookie header.
+func (s *Session) CookieHeader() string {
+	pairs := make([]string, 0, len(s.Cookies))
+	for _, cookie := range s.Cookies {
+		pairs = append(pairs, cookie.Name+"="+cookie.Value)
+	}
+	return strings.Join(pairs, "; ")
+}
+
+type cookieJarKey struct{}
+
+// withCookieJar makes the requests sent with the returned context keep their
+// cookies in jar instead of the client's own jar.
+func withCookieJar(ctx context.Context, jar http.CookieJar) context.Context {
+	return context.WithValue(ctx, cookieJarKey{}, jar)
+}
+
+// OpenSession logs in with the client's credentials through the same flow as
+// Login, but keeps the resulting session apart from the client's, so that
+// closing it leaves the client signed in. Clients authenticated with an API
+// token have no session to open and hand out their token instead.
+func (c *Client) OpenSession(ctx context.Context) (*Session, error) {
+	endpoint := c.endpointOrder()[0]
+	if c.apiToken != "" {
+		return &Session{Endpoint: endpoint, Token: c.apiToken}, nil
+	}
+
+	var err error
+	for _, endpoint = range c.endpointOrder() {
+		// cookiejar.New only fails on invalid options.
+		jar, _ := cookiejar.New(nil)
+		if err = c.login(withCookieJar(ctx, jar), endpoint); err == nil {
+			u, err := url.Parse(endpoint + apiVersionPrefix + "/")
+			if err != nil {
+				return nil, err
+			}
+			return &Session{Endpoint: endpoint, Cookies: jar.Cookies(u)}, nil
+		}
+		if !isDialError(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// CloseSession logs a session opened by OpenSession out of its Controller. A
+// Controller without a logout route leaves the session to expire on its own.
+func (c *Client) CloseSession(ctx context.Context, session *Session) error {
+	if session.Token != "" || len(session.Cookies) == 0 {
+		return nil
+	}
+
+	jar, _ := cookiejar.New(nil)
+	u, err := url.Parse(session.Endpoint + apiVersionPrefix + "/")
+	if err != nil {
+		return err
+	}
+	jar.SetCookies(u, session.Cookies)
+
+	req, err := http.NewRequestWithContext(withCookieJar(ctx, jar), http.MethodPost, session.Endpoint+apiVersionPrefix+"/user/logout", nil)
+	if err != nil {
+		return err
+	}
+
+	res, body, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	switch {
+	case res.StatusCode == http.StatusNotFound, res.StatusCode == http.StatusUnauthorized:
+		// Nothing to log out of: no logout route, or the session has
+		// already expired.
+		return nil
+	case res.StatusCode >= 400:
+		return newAPIError(req, res, body)
+	}
+	return nil
+}
+
+// MarshalJSON and UnmarshalJSON let a session be carried between the calls
+// that open and close it, such as in an ephemeral resource's private data.
+func (s Session) MarshalJSON() ([]byte, error) {
+	cookies := map[string]string{}
+	for _, cookie := range s.Cookies {
+		cookies[cookie.Name] = cookie.Value
+	}
+	return json.Marshal(sessionJSON{Endpoint: s.Endpoint, Cookies: cookies, Token: s.Token})
+}
+
+func (s *Session) UnmarshalJSON(data []byte) error {
+	var decoded sessionJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Endpoint == "" {
+		return errors.New("session has no endpoint")
+	}
+	s.Endpoint = decoded.Endpoint
+	s.Token = decoded.Token
+	s.Cookies = nil
+	for name, value := range decoded.Cookies {
+		s.Cookies = append(s.Cookies, &http.Cookie{Name: name, Value: value})
+	}
+	return nil
+}
+
+type sessionJSON struct {
+	Endpoint string            `json:"endpoint"`
+	Cookies  map[string]string `json:"cookies,omitempty"`
+	Token    string            `json:"token,omitempty"`
+}
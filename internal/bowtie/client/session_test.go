@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestOpenSessionKeepsClientSessionApart(t *testing.T) {
+	var loggedOut string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/-net/api/v0/user/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "opened", Path: "/"})
+			w.WriteHeader(http.StatusOK)
+		case "/-net/api/v0/user/logout":
+			if cookie, err := r.Cookie("session"); err == nil {
+				loggedOut = cookie.Value
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.auth = AuthPayload{Username: "admin@example.com", Password: "hunter2"}
+	ctx := context.Background()
+
+	session, err := c.OpenSession(ctx)
+	if err != nil {
+		t.Fatalf("OpenSession: %v", err)
+	}
+	if session.Endpoint != ts.URL || session.CookieHeader() != "session=opened" {
+		t.Errorf("unexpected session %+v", session)
+	}
+	if got := session.Headers()["Cookie"]; got != "session=opened" {
+		t.Errorf("Cookie header = %q", got)
+	}
+
+	u, _ := url.Parse(ts.URL)
+	if cookies := c.HTTPClient.Jar.Cookies(u); len(cookies) != 1 || cookies[0].Value != "test" {
+		t.Errorf("the client's own session was replaced: %v", cookies)
+	}
+
+	// The session survives being carried between Open and Close.
+	encoded, err := json.Marshal(session)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var decoded Session
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if err := c.CloseSession(ctx, &decoded); err != nil {
+		t.Fatalf("CloseSession: %v", err)
+	}
+	if loggedOut != "opened" {
+		t.Errorf("logged out session %q, want the opened one", loggedOut)
+	}
+}
+
+func TestOpenSessionHandsOutAPIToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	c.apiToken = "tok-123"
+
+	session, err := c.OpenSession(context.Background())
+	if err != nil {
+		t.Fatalf("OpenSession: %v", err)
+	}
+	if got := session.Headers()["Authorization"]; got != "Bearer tok-123" {
+		t.Errorf("Authorization header = %q", got)
+	}
+	if err := c.CloseSession(context.Background(), session); err != nil {
+		t.Errorf("CloseSession: %v", err)
+	}
+}
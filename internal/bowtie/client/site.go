@@ -1,14 +1,15 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) ListSites() ([]Site, error) {
-	org, err := c.GetOrganization()
+func (c *Client) ListSites(ctx context.Context) ([]Site, error) {
+	org, err := c.GetOrganization(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -16,8 +17,8 @@ func (c *Client) ListSites() ([]Site, error) {
 	return org.Sites, nil
 }
 
-func (c *Client) GetSites() ([]Site, error) {
-	org, err := c.GetOrganization()
+func (c *Client) GetSites(ctx context.Context) ([]Site, error) {
+	org, err := c.GetOrganization(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +33,7 @@ func FindSite(siteID string, sites []Site) (*Site, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("site not found: %s", siteID)
+	return nil, fmt.Errorf("site %s: %w", siteID, ErrNotFound)
 }
 
 type SiteUpsertPayload struct {
@@ -40,8 +41,8 @@ type SiteUpsertPayload struct {
 	Name string `json:"name"`
 }
 
-func (c *Client) CreateSite(id string, name string) error {
-	err := c.UpsertSite(id, name)
+func (c *Client) CreateSite(ctx context.Context, id string, name string) error {
+	err := c.UpsertSite(ctx, id, name)
 	if err != nil {
 		return err
 	}
@@ -49,7 +50,7 @@ func (c *Client) CreateSite(id string, name string) error {
 	return nil
 }
 
-func (c *Client) UpsertSite(id, name string) error {
+func (c *Client) UpsertSite(ctx context.Context, id, name string) error {
 	payload := SiteUpsertPayload{
 		ID:   id,
 		Name: name,
@@ -60,7 +61,7 @@ func (c *Client) UpsertSite(id, name string) error {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/site"), strings.NewReader(string(requestPayload)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/site"), strings.NewReader(string(requestPayload)))
 	if err != nil {
 		return err
 	}
@@ -85,8 +86,8 @@ type siteRangePayload struct {
 	Metric      int64  `json:"metric"`
 }
 
-func (c *Client) DeleteSite(siteID string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/site/%s", siteID)), nil)
+func (c *Client) DeleteSite(ctx context.Context, siteID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/site/%s", siteID)), nil)
 	if err != nil {
 		return err
 	}
@@ -95,7 +96,7 @@ func (c *Client) DeleteSite(siteID string) error {
 	return err
 }
 
-func (c *Client) UpsertSiteRange(siteID, id, name, description, cidr string, isV4, isV6 bool, weight, metric int64) error {
+func (c *Client) UpsertSiteRange(ctx context.Context, siteID, id, name, description, cidr string, isV4, isV6 bool, weight, metric int64) error {
 	payload := siteRangePayload{
 		ID:          id,
 		SiteID:      siteID,
@@ -112,7 +113,7 @@ func (c *Client) UpsertSiteRange(siteID, id, name, description, cidr string, isV
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL(fmt.Sprintf("/site/%s/range", siteID)), strings.NewReader(string(requestBody)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL(fmt.Sprintf("/site/%s/range", siteID)), strings.NewReader(string(requestBody)))
 	if err != nil {
 		return err
 	}
@@ -121,8 +122,8 @@ func (c *Client) UpsertSiteRange(siteID, id, name, description, cidr string, isV
 	return err
 }
 
-func (c *Client) DeleteSiteRange(siteID, id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/site/%s/range/%s", siteID, id)), nil)
+func (c *Client) DeleteSiteRange(ctx context.Context, siteID, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/site/%s/range/%s", siteID, id)), nil)
 	if err != nil {
 		return err
 	}
@@ -148,5 +149,5 @@ func (c *Client) FindSiteRange(site *Site, id string) (*RoutableRange, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("routable range not found in site: range: %s", id)
+	return nil, fmt.Errorf("routable range %s in site %s: %w", id, site.ID, ErrNotFound)
 }
@@ -1,10 +1,20 @@
 package client
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func getThrough(t *testing.T, transport *http.Transport, url string) error {
@@ -25,7 +35,7 @@ func TestBuildTransportTLSModes(t *testing.T) {
 	defer ts.Close()
 
 	// Default: full verification, self-signed server cert is not trusted.
-	verifying, err := buildTransport(false, "")
+	verifying, err := buildTransport(false, "", clientOptions{})
 	if err != nil {
 		t.Fatalf("buildTransport: %v", err)
 	}
@@ -34,7 +44,7 @@ func TestBuildTransportTLSModes(t *testing.T) {
 	}
 
 	// insecure: verification skipped, request succeeds.
-	insecure, err := buildTransport(true, "")
+	insecure, err := buildTransport(true, "", clientOptions{})
 	if err != nil {
 		t.Fatalf("buildTransport: %v", err)
 	}
@@ -47,7 +57,7 @@ func TestBuildTransportTLSModes(t *testing.T) {
 
 	// ca_bundle: the server's own cert is trusted while still verifying.
 	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
-	trusted, err := buildTransport(false, string(certPEM))
+	trusted, err := buildTransport(false, string(certPEM), clientOptions{})
 	if err != nil {
 		t.Fatalf("buildTransport with ca_bundle: %v", err)
 	}
@@ -63,7 +73,71 @@ func TestBuildTransportTLSModes(t *testing.T) {
 }
 
 func TestBuildTransportRejectsInvalidCABundle(t *testing.T) {
-	if _, err := buildTransport(false, "-----BEGIN CERTIFICATE-----\nnot base64\n-----END CERTIFICATE-----"); err == nil {
+	if _, err := buildTransport(false, "-----BEGIN CERTIFICATE-----\nnot base64\n-----END CERTIFICATE-----", clientOptions{}); err == nil {
 		t.Error("expected an error for a ca_bundle with no valid certificates")
 	}
 }
+
+// clientCertificatePEM generates a self-signed client certificate and its key.
+func clientCertificatePEM(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestBuildTransportPresentsClientCertificate(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	ts.StartTLS()
+	defer ts.Close()
+
+	without, err := buildTransport(true, "", clientOptions{})
+	if err != nil {
+		t.Fatalf("buildTransport: %v", err)
+	}
+	if err := getThrough(t, without, ts.URL); err == nil {
+		t.Error("expected the mutual TLS server to reject a client without a certificate")
+	}
+
+	// The certificate is given inline and the key as a path to a file.
+	certPEM, keyPEM := clientCertificatePEM(t)
+	keyPath := filepath.Join(t.TempDir(), "client.key")
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	with, err := buildTransport(true, "", clientOptions{clientCertificate: string(certPEM), clientKey: keyPath})
+	if err != nil {
+		t.Fatalf("buildTransport with a client certificate: %v", err)
+	}
+	if err := getThrough(t, with, ts.URL); err != nil {
+		t.Errorf("request with a client certificate should succeed: %v", err)
+	}
+}
+
+func TestBuildTransportRequiresCertificateAndKeyTogether(t *testing.T) {
+	certPEM, _ := clientCertificatePEM(t)
+	if _, err := buildTransport(false, "", clientOptions{clientCertificate: string(certPEM)}); err == nil {
+		t.Error("expected an error for a client_certificate without a client_key")
+	}
+}
@@ -21,8 +21,8 @@ type BowtieUser struct {
 	Role              string `json:"role,omitempty"`
 }
 
-func (c *Client) GetUsers() (map[string]BowtieUser, error) {
-	req, err := http.NewRequest(http.MethodGet, c.getHostURL("/users"), nil)
+func (c *Client) GetUsers(ctx context.Context) (map[string]BowtieUser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getHostURL("/users"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (c *Client) GetUsers() (map[string]BowtieUser, error) {
 }
 
 func (c *Client) GetUserByEmail(ctx context.Context, email string) (BowtieUser, error) {
-	users, err := c.GetUsers()
+	users, err := c.GetUsers(ctx)
 	if err != nil {
 		return BowtieUser{}, err
 	}
@@ -49,11 +49,11 @@ func (c *Client) GetUserByEmail(ctx context.Context, email string) (BowtieUser,
 		}
 	}
 
-	return BowtieUser{}, fmt.Errorf("user not found")
+	return BowtieUser{}, fmt.Errorf("user with email %q: %w", email, ErrNotFound)
 }
 
-func (c *Client) GetUser(id string) (BowtieUser, error) {
-	req, err := http.NewRequest(http.MethodGet, c.getHostURL(fmt.Sprintf("/user/%s", id)), nil)
+func (c *Client) GetUser(ctx context.Context, id string) (BowtieUser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getHostURL(fmt.Sprintf("/user/%s", id)), nil)
 	if err != nil {
 		return BowtieUser{}, err
 	}
@@ -69,7 +69,7 @@ func (c *Client) GetUser(id string) (BowtieUser, error) {
 }
 
 func (c *Client) DeleteUser(ctx context.Context, id string) error {
-	req, err := http.NewRequest(http.MethodDelete, c.getHostURL(fmt.Sprintf("/user/%s", id)), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.getHostURL(fmt.Sprintf("/user/%s", id)), nil)
 	if err != nil {
 		return err
 	}
@@ -89,7 +89,7 @@ func (c *Client) DisableUser(ctx context.Context, id string) error {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/user/upsert"), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/user/upsert"), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -98,7 +98,7 @@ func (c *Client) DisableUser(ctx context.Context, id string) error {
 	return err
 }
 
-func (c *Client) UpsertUser(id, name, email, role string, authzPolicies, authzUsers, authzControlPlane, authzDevices, enabled bool) (string, error) {
+func (c *Client) UpsertUser(ctx context.Context, id, name, email, role string, authzPolicies, authzUsers, authzControlPlane, authzDevices, enabled bool) (string, error) {
 	payload := BowtieUser{
 		ID:                id,
 		Name:              name,
@@ -120,7 +120,7 @@ func (c *Client) UpsertUser(id, name, email, role string, authzPolicies, authzUs
 		return "", err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.getHostURL("/user/upsert"), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.getHostURL("/user/upsert"), bytes.NewBuffer(body))
 	if err != nil {
 		return "", err
 	}
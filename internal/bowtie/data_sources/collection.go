@@ -77,7 +77,7 @@ func (d *collectionDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	collections, err := d.client.GetCollections()
+	collections, err := d.client.GetCollections(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read collections", err.Error())
 		return
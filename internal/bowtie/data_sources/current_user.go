@@ -0,0 +1,123 @@
+package data_sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &currentUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &currentUserDataSource{}
+)
+
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &currentUserDataSource{}
+}
+
+type currentUserDataSource struct {
+	client *client.Client
+}
+
+type currentUserModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Email             types.String `tfsdk:"email"`
+	Role              types.String `tfsdk:"role"`
+	AuthzDevices      types.Bool   `tfsdk:"authz_devices"`
+	AuthzPolicies     types.Bool   `tfsdk:"authz_policies"`
+	AuthzControlPlane types.Bool   `tfsdk:"authz_control_plane"`
+	AuthzUsers        types.Bool   `tfsdk:"authz_users"`
+}
+
+func (u *currentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+func (u *currentUserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Reference the account the provider is authenticated as, for example to check its permissions
+before managing resources that require them.
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Internal resource ID.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The given name for the user.",
+			},
+			"email": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifying login address.",
+			},
+			"role": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The role the user is assigned, such as `Owner`, `User`, `FullAdministrator`, or `LimitedAdministrator`.",
+			},
+			"authz_devices": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the user is authorized to administer organization devices.",
+			},
+			"authz_policies": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the user is authorized to administer organization policies.",
+			},
+			"authz_control_plane": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the user is authorized to administer an organization's control plane configuration.",
+			},
+			"authz_users": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the user is authorized to update an organization's users.",
+			},
+		},
+	}
+}
+
+func (u *currentUserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected *client.Client, got: %T, please report this to the provider.", req.ProviderData),
+		)
+		return
+	}
+
+	u.client = client
+}
+
+func (u *currentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	me, err := u.client.WhoAmI(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to retrieve current user",
+			"Unexpected error retrieving the authenticated user: "+err.Error(),
+		)
+		return
+	}
+
+	state := currentUserModel{
+		ID:                types.StringValue(me.User.ID),
+		Name:              types.StringValue(me.User.Name),
+		Email:             types.StringValue(me.User.Email),
+		Role:              types.StringValue(me.User.Role),
+		AuthzDevices:      types.BoolValue(me.User.AuthZDevices),
+		AuthzPolicies:     types.BoolValue(me.User.AuthZPolicies),
+		AuthzControlPlane: types.BoolValue(me.User.AuthZControlPlane),
+		AuthzUsers:        types.BoolValue(me.User.AuthZUsers),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -87,7 +87,7 @@ func (d *deviceDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	devices, err := d.client.ListDevices()
+	devices, err := d.client.ListDevices(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read devices", err.Error())
 		return
@@ -77,7 +77,7 @@ func (d *deviceGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	groups, err := d.client.GetDeviceGroups()
+	groups, err := d.client.GetDeviceGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read device groups", err.Error())
 		return
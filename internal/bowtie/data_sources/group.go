@@ -72,7 +72,7 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	groups, err := d.client.GetGroups()
+	groups, err := d.client.GetGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read user groups", err.Error())
 		return
@@ -84,7 +84,7 @@ func (d *resourceGroupDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	groups, err := d.client.GetResourceGroups()
+	groups, err := d.client.GetResourceGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read resource groups", err.Error())
 		return
@@ -0,0 +1,149 @@
+package ephemeral_resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &sessionEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &sessionEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &sessionEphemeralResource{}
+)
+
+// sessionPrivateKey holds the opened session between Open and Close.
+const sessionPrivateKey = "session"
+
+func NewSessionEphemeralResource() ephemeral.EphemeralResource {
+	return &sessionEphemeralResource{}
+}
+
+type sessionEphemeralResource struct {
+	client *client.Client
+}
+
+type sessionModel struct {
+	Host    types.String `tfsdk:"host"`
+	Cookie  types.String `tfsdk:"cookie"`
+	Token   types.String `tfsdk:"token"`
+	Headers types.Map    `tfsdk:"headers"`
+}
+
+func (s *sessionEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session"
+}
+
+func (s *sessionEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Open a short-lived Controller session with the provider's credentials, for other providers and
+scripts in the same run that call Controller endpoints directly, such as an ` + "`http`" + ` data source.
+The session is never written to state or plan files, and is logged out once Terraform no longer
+needs it. When the provider authenticates with an API token, the token is handed out instead.
+Requires Terraform 1.10 or later.
+`,
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Controller endpoint the session belongs to. Send requests to this endpoint, since other Controllers do not recognize the session.",
+			},
+			"cookie": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The session cookies as the value of a `Cookie` header. Null when the provider authenticates with an API token.",
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The provider's API token. Null when the provider logs in with a username and password.",
+			},
+			"headers": schema.MapAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The HTTP headers that authenticate a request as the session, ready for `request_headers` of an `http` data source.",
+			},
+		},
+	}
+}
+
+func (s *sessionEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected *client.Client, got: %T, please report this to the provider.", req.ProviderData),
+		)
+		return
+	}
+
+	s.client = client
+}
+
+func (s *sessionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	session, err := s.client.OpenSession(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to open Bowtie session",
+			"Unexpected error logging in to the Controller: "+err.Error(),
+		)
+		return
+	}
+
+	headers, diags := types.MapValueFrom(ctx, types.StringType, session.Headers())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result := sessionModel{
+		Host:    types.StringValue(session.Endpoint),
+		Cookie:  types.StringNull(),
+		Token:   types.StringNull(),
+		Headers: headers,
+	}
+	if session.Token != "" {
+		result.Token = types.StringValue(session.Token)
+	} else {
+		result.Cookie = types.StringValue(session.CookieHeader())
+	}
+
+	private, err := json.Marshal(session)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to record Bowtie session", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionPrivateKey, private)...)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
+
+func (s *sessionEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, sessionPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var session client.Session
+	if err := json.Unmarshal(private, &session); err != nil {
+		resp.Diagnostics.AddError("Failed to read Bowtie session", err.Error())
+		return
+	}
+
+	if err := s.client.CloseSession(ctx, &session); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Failed to close Bowtie session",
+			"The session could not be logged out and stays valid until it expires: "+err.Error(),
+		)
+	}
+}
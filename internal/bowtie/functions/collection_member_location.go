@@ -0,0 +1,77 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/resources"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &collectionMemberLocationFunction{}
+
+// locationAttributeTypes matches the location object of bowtie_collection
+// members and of bowtie_resource, so the result can be assigned to either.
+var locationAttributeTypes = map[string]attr.Type{
+	"ip":         types.StringType,
+	"cidr":       types.StringType,
+	"dns":        types.StringType,
+	"collection": types.StringType,
+}
+
+func NewCollectionMemberLocationFunction() function.Function {
+	return &collectionMemberLocationFunction{}
+}
+
+type collectionMemberLocationFunction struct{}
+
+func (f *collectionMemberLocationFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "collection_member_location"
+}
+
+func (f *collectionMemberLocationFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a location object from an IP address, CIDR range or DNS name.",
+		MarkdownDescription: "Infers whether `value` is an IP address, a CIDR range or a DNS name and returns a location object with " +
+			"exactly one of `ip`, `cidr` or `dns` set, suitable for the `location` of a `bowtie_collection` member or a `bowtie_resource`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "An IP address such as `10.0.0.1`, a CIDR range such as `10.0.0.0/8`, or a DNS name such as `*.example.com`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: locationAttributeTypes,
+		},
+	}
+}
+
+func (f *collectionMemberLocationFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	locationType, err := resources.InferLocationType(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	attributes := map[string]attr.Value{
+		"ip":         types.StringNull(),
+		"cidr":       types.StringNull(),
+		"dns":        types.StringNull(),
+		"collection": types.StringNull(),
+	}
+	attributes[locationType] = types.StringValue(value)
+
+	location, diags := types.ObjectValue(locationAttributeTypes, attributes)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, location))
+}
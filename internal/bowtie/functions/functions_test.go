@@ -0,0 +1,95 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// run calls f with args, returning its result or failing the test when the
+// function reports an error and wantErr is false.
+func run(t *testing.T, f function.Function, wantErr bool, args ...attr.Value) attr.Value {
+	t.Helper()
+	ctx := context.Background()
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+	result, funcErr := definition.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatalf("NewResultData: %s", funcErr)
+	}
+
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	if wantErr {
+		if resp.Error == nil {
+			t.Fatalf("expected an error, got %s", resp.Result.Value())
+		}
+		return nil
+	}
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	return resp.Result.Value()
+}
+
+func TestCollectionMemberLocationInfersType(t *testing.T) {
+	cases := map[string]string{
+		"10.0.0.1":      "ip",
+		"2001:db8::1":   "ip",
+		"10.0.0.0/8":    "cidr",
+		"2001:db8::/32": "cidr",
+		"example.com":   "dns",
+		"*.example.com": "dns",
+	}
+	for value, wantType := range cases {
+		location := run(t, NewCollectionMemberLocationFunction(), false, types.StringValue(value)).(types.Object)
+		for name, attribute := range location.Attributes() {
+			got := attribute.(types.String)
+			if name == wantType {
+				if got.ValueString() != value {
+					t.Errorf("%s: %s = %s, want %s", value, name, got, value)
+				}
+			} else if !got.IsNull() {
+				t.Errorf("%s: %s = %s, want null", value, name, got)
+			}
+		}
+	}
+
+	for _, value := range []string{"", "10.0.0.300", "not a host", "-bad.example.com"} {
+		run(t, NewCollectionMemberLocationFunction(), true, types.StringValue(value))
+	}
+}
+
+func TestPortRange(t *testing.T) {
+	got := run(t, NewPortRangeFunction(), false, types.Int64Value(80), types.Int64Value(443))
+	want := types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(80), types.Int64Value(443)})
+	if !got.Equal(want) {
+		t.Errorf("port_range(80, 443) = %s, want %s", got, want)
+	}
+
+	run(t, NewPortRangeFunction(), true, types.Int64Value(443), types.Int64Value(80))
+	run(t, NewPortRangeFunction(), true, types.Int64Value(-1), types.Int64Value(80))
+	run(t, NewPortRangeFunction(), true, types.Int64Value(1), types.Int64Value(65536))
+}
+
+func TestNormalizeCIDR(t *testing.T) {
+	cases := map[string]string{
+		"10.1.2.3/8":        "10.0.0.0/8",
+		"192.168.1.0/24":    "192.168.1.0/24",
+		"2001:DB8:0:0::/32": "2001:db8::/32",
+		"10.0.0.1":          "10.0.0.1/32",
+		"2001:db8::1":       "2001:db8::1/128",
+	}
+	for value, want := range cases {
+		got := run(t, NewNormalizeCIDRFunction(), false, types.StringValue(value))
+		if got.(types.String).ValueString() != want {
+			t.Errorf("normalize_cidr(%q) = %s, want %q", value, got, want)
+		}
+	}
+
+	run(t, NewNormalizeCIDRFunction(), true, types.StringValue("10.0.0.0/33"))
+	run(t, NewNormalizeCIDRFunction(), true, types.StringValue("example.com"))
+}
@@ -0,0 +1,63 @@
+package functions
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/resources"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &normalizeCIDRFunction{}
+
+func NewNormalizeCIDRFunction() function.Function {
+	return &normalizeCIDRFunction{}
+}
+
+type normalizeCIDRFunction struct{}
+
+func (f *normalizeCIDRFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_cidr"
+}
+
+func (f *normalizeCIDRFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Rewrite a CIDR range in the canonical form the Controller stores.",
+		MarkdownDescription: "Clears the host bits of a CIDR range and writes IPv6 ranges in their shortest lowercase form, so that " +
+			"`10.1.2.3/8` becomes `10.0.0.0/8` and `2001:DB8:0:0::/32` becomes `2001:db8::/32`. A bare address is treated as a " +
+			"single-host range (`/32` or `/128`). Use it to avoid perpetual diffs against the value the Controller reads back.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "An IPv4 or IPv6 CIDR range, or a single address.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeCIDRFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	if !strings.Contains(value, "/") {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, "not a CIDR range or IP address: "+err.Error())
+			return
+		}
+		value = netip.PrefixFrom(addr, addr.BitLen()).String()
+	}
+
+	prefix, err := resources.ParseCIDRPrefix(value, 0)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "not a CIDR range or IP address: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, prefix.Masked().String()))
+}
@@ -0,0 +1,216 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/resources"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &policyPredicateJSONFunction{}
+
+// predicateSourceNamespace seeds the IDs given to nested operands. Functions
+// must return the same result for the same arguments, so the IDs are derived
+// from the operands instead of generated randomly.
+var predicateSourceNamespace = uuid.MustParse("0f6d2a52-3c1e-4f0b-9a57-6c2a4e1b8d90")
+
+var predicateMatchers = map[string]bool{
+	"always":             true,
+	"authenticated_user": true,
+	"user":               true,
+	"device":             true,
+	"user_group":         true,
+	"device_group":       true,
+}
+
+var predicateGroups = map[string]bool{
+	"and": true,
+	"or":  true,
+	"nor": true,
+}
+
+func NewPolicyPredicateJSONFunction() function.Function {
+	return &policyPredicateJSONFunction{}
+}
+
+type policyPredicateJSONFunction struct{}
+
+func (f *policyPredicateJSONFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "policy_predicate_json"
+}
+
+func (f *policyPredicateJSONFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render a policy source predicate as the Controller's JSON.",
+		MarkdownDescription: "Takes an object shaped like the `source` of `bowtie_policy` - exactly one of `always`, `authenticated_user`, " +
+			"`user`, `device`, `user_group`, `device_group`, or an `and`, `or` or `nor` list of further such objects - and returns " +
+			"the predicate in the Controller's wire format, for example `{\"InUserGroup\":\"<id>\"}`. Unlike the resource, groups " +
+			"may nest to any depth. Nested operands are given IDs derived from their contents, so the result is stable.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "source",
+				MarkdownDescription: "The source predicate object.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *policyPredicateJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var source types.Dynamic
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &source))
+	if resp.Error != nil {
+		return
+	}
+
+	predicate, err := predicateFromValue(source.UnderlyingValue(), "source")
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	rendered, err := json.Marshal(predicate)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, string(rendered)))
+}
+
+// predicateFromValue converts one source object, found at where, into a
+// predicate, recursing into its and/or/nor groups.
+func predicateFromValue(value attr.Value, where string) (client.BowtiePredicate, error) {
+	attributes, ok := objectAttributes(value)
+	if !ok {
+		return client.BowtiePredicate{}, fmt.Errorf("%s must be an object", where)
+	}
+
+	matchers := map[string]attr.Value{}
+	groups := map[string][]client.BowtiePolicySource{}
+	for name, attribute := range attributes {
+		if attribute.IsNull() {
+			continue
+		}
+		attributeWhere := where + "." + name
+		switch {
+		case predicateMatchers[name]:
+			matchers[name] = attribute
+		case predicateGroups[name]:
+			sources, err := sourcesFromValue(attribute, attributeWhere)
+			if err != nil {
+				return client.BowtiePredicate{}, err
+			}
+			groups[name] = sources
+		default:
+			return client.BowtiePredicate{}, fmt.Errorf("%s is not a source predicate attribute", attributeWhere)
+		}
+	}
+
+	always, err := boolMatcher(matchers, "always", where)
+	if err != nil {
+		return client.BowtiePredicate{}, err
+	}
+	authenticatedUser, err := boolMatcher(matchers, "authenticated_user", where)
+	if err != nil {
+		return client.BowtiePredicate{}, err
+	}
+	var strs [4]types.String
+	for i, name := range []string{"user", "device", "user_group", "device_group"} {
+		if strs[i], err = stringMatcher(matchers, name, where); err != nil {
+			return client.BowtiePredicate{}, err
+		}
+	}
+
+	predicate, err := resources.SourcePredicate(always, authenticatedUser, strs[0], strs[1], strs[2], strs[3], groups["and"], groups["or"], groups["nor"])
+	if err != nil {
+		return client.BowtiePredicate{}, fmt.Errorf("%s: %w", where, err)
+	}
+	return predicate, nil
+}
+
+// sourcesFromValue converts an and/or/nor list into operand sources.
+func sourcesFromValue(value attr.Value, where string) ([]client.BowtiePolicySource, error) {
+	var elements []attr.Value
+	switch v := value.(type) {
+	case basetypes.TupleValue:
+		elements = v.Elements()
+	case basetypes.ListValue:
+		elements = v.Elements()
+	case basetypes.SetValue:
+		elements = v.Elements()
+	case basetypes.DynamicValue:
+		return sourcesFromValue(v.UnderlyingValue(), where)
+	default:
+		return nil, fmt.Errorf("%s must be a list of source objects", where)
+	}
+
+	sources := make([]client.BowtiePolicySource, 0, len(elements))
+	for i, element := range elements {
+		elementWhere := fmt.Sprintf("%s[%d]", where, i)
+		predicate, err := predicateFromValue(element, elementWhere)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := json.Marshal(predicate)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, client.BowtiePolicySource{
+			ID:        uuid.NewSHA1(predicateSourceNamespace, []byte(elementWhere+" "+string(rendered))).String(),
+			Predicate: predicate,
+		})
+	}
+	return sources, nil
+}
+
+func objectAttributes(value attr.Value) (map[string]attr.Value, bool) {
+	switch v := value.(type) {
+	case basetypes.ObjectValue:
+		return v.Attributes(), true
+	case basetypes.MapValue:
+		return v.Elements(), true
+	case basetypes.DynamicValue:
+		return objectAttributes(v.UnderlyingValue())
+	}
+	return nil, false
+}
+
+func boolMatcher(matchers map[string]attr.Value, name, where string) (types.Bool, error) {
+	value, ok := matchers[name]
+	if !ok {
+		return types.BoolNull(), nil
+	}
+	if b, ok := value.(basetypes.BoolValue); ok {
+		return b, nil
+	}
+	if s, ok := value.(basetypes.StringValue); ok {
+		// Maps coerce their values to a common type, turning true into "true".
+		switch strings.ToLower(s.ValueString()) {
+		case "true":
+			return types.BoolValue(true), nil
+		case "false":
+			return types.BoolValue(false), nil
+		}
+	}
+	return types.BoolNull(), fmt.Errorf("%s.%s must be a bool", where, name)
+}
+
+func stringMatcher(matchers map[string]attr.Value, name, where string) (types.String, error) {
+	value, ok := matchers[name]
+	if !ok {
+		return types.StringNull(), nil
+	}
+	if s, ok := value.(basetypes.StringValue); ok {
+		return s, nil
+	}
+	return types.StringNull(), fmt.Errorf("%s.%s must be a string", where, name)
+}
@@ -0,0 +1,84 @@
+package functions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func object(attributes map[string]attr.Value) types.Object {
+	attributeTypes := map[string]attr.Type{}
+	for name, value := range attributes {
+		attributeTypes[name] = value.Type(nil)
+	}
+	return types.ObjectValueMust(attributeTypes, attributes)
+}
+
+func tuple(elements ...attr.Value) types.Tuple {
+	elementTypes := make([]attr.Type, 0, len(elements))
+	for _, element := range elements {
+		elementTypes = append(elementTypes, element.Type(nil))
+	}
+	return types.TupleValueMust(elementTypes, elements)
+}
+
+func TestPolicyPredicateJSONRendersMatchers(t *testing.T) {
+	cases := []struct {
+		source types.Object
+		want   string
+	}{
+		{object(map[string]attr.Value{"always": types.BoolValue(true)}), `"Always"`},
+		{object(map[string]attr.Value{"authenticated_user": types.BoolValue(true)}), `"AuthenticatedUser"`},
+		{object(map[string]attr.Value{"user_group": types.StringValue("g-1")}), `{"InUserGroup":"g-1"}`},
+		{object(map[string]attr.Value{"device_group": types.StringValue("d-1"), "user": types.StringNull()}), `{"InDeviceGroup":"d-1"}`},
+	}
+	for _, tc := range cases {
+		got := run(t, NewPolicyPredicateJSONFunction(), false, types.DynamicValue(tc.source))
+		if got.(types.String).ValueString() != tc.want {
+			t.Errorf("policy_predicate_json(%s) = %s, want %s", tc.source, got, tc.want)
+		}
+	}
+}
+
+func TestPolicyPredicateJSONNestsGroupsDeterministically(t *testing.T) {
+	source := object(map[string]attr.Value{
+		"and": tuple(
+			object(map[string]attr.Value{"user_group": types.StringValue("g-1")}),
+			object(map[string]attr.Value{"nor": tuple(
+				object(map[string]attr.Value{"device": types.StringValue("dev-1")}),
+			)}),
+		),
+	})
+
+	first := run(t, NewPolicyPredicateJSONFunction(), false, types.DynamicValue(source)).(types.String).ValueString()
+	second := run(t, NewPolicyPredicateJSONFunction(), false, types.DynamicValue(source)).(types.String).ValueString()
+	if first != second {
+		t.Errorf("results differ between calls:\n%s\n%s", first, second)
+	}
+
+	var predicate client.BowtiePredicate
+	if err := json.Unmarshal([]byte(first), &predicate); err != nil {
+		t.Fatalf("rendered predicate does not decode: %v\n%s", err, first)
+	}
+	if len(predicate.And) != 2 || predicate.And[0].Predicate.InUserGroup != "g-1" || predicate.And[0].ID == "" {
+		t.Fatalf("unexpected and group: %+v", predicate.And)
+	}
+	if nor := predicate.And[1].Predicate.Nor; len(nor) != 1 || nor[0].Predicate.Device != "dev-1" {
+		t.Errorf("unexpected nested nor group: %+v", nor)
+	}
+}
+
+func TestPolicyPredicateJSONRejectsInvalidSources(t *testing.T) {
+	for _, source := range []types.Object{
+		object(map[string]attr.Value{}),
+		object(map[string]attr.Value{"user": types.StringValue("u-1"), "device": types.StringValue("d-1")}),
+		object(map[string]attr.Value{"users": types.StringValue("u-1")}),
+		object(map[string]attr.Value{"always": types.StringValue("yes")}),
+		object(map[string]attr.Value{"and": types.StringValue("u-1")}),
+	} {
+		run(t, NewPolicyPredicateJSONFunction(), true, types.DynamicValue(source))
+	}
+}
@@ -0,0 +1,64 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &portRangeFunction{}
+
+func NewPortRangeFunction() function.Function {
+	return &portRangeFunction{}
+}
+
+type portRangeFunction struct{}
+
+func (f *portRangeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "port_range"
+}
+
+func (f *portRangeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build an inclusive port range.",
+		MarkdownDescription: "Returns the two-element list expected by the `ports.range` attribute of `bowtie_resource`, after checking that both ports are between 0 and 65535 and in order.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "from",
+				MarkdownDescription: "The first port of the range.",
+			},
+			function.Int64Parameter{
+				Name:                "to",
+				MarkdownDescription: "The last port of the range, no lower than `from`.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.Int64Type,
+		},
+	}
+}
+
+func (f *portRangeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var from, to int64
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &from, &to))
+	if resp.Error != nil {
+		return
+	}
+
+	for i, port := range []int64{from, to} {
+		if port < 0 || port > 65535 {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(int64(i), fmt.Sprintf("port %d is not between 0 and 65535", port)))
+		}
+	}
+	if resp.Error != nil {
+		return
+	}
+	if from > to {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("the range ends at port %d, before it starts at port %d", to, from))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, []int64{from, to}))
+}
@@ -0,0 +1,197 @@
+package list_resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ list.ListResource              = &bowtieListResource{}
+	_ list.ListResourceWithConfigure = &bowtieListResource{}
+)
+
+// listedObject is one object found on the Controller.
+type listedObject struct {
+	// Name is shown to the practitioner and matched by the name filters.
+	Name string
+	// ImportID is the identifier `terraform import` takes for the object.
+	ImportID string
+	// Identity holds the resource identity attributes of the object.
+	Identity map[string]string
+}
+
+// bowtieListResource lists the objects of one managed resource type. Results
+// carry the identity of each object; when Terraform asks for the resource
+// too, the object is imported and read through the managed resource itself,
+// so the generated configuration matches what `terraform import` produces.
+type bowtieListResource struct {
+	typeName    string
+	newResource func() resource.Resource
+	list        func(ctx context.Context, c *client.Client) ([]listedObject, error)
+
+	client *client.Client
+}
+
+type listConfigModel struct {
+	Name         types.String `tfsdk:"name"`
+	NameContains types.String `tfsdk:"name_contains"`
+}
+
+func (l *bowtieListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + l.typeName
+}
+
+func (l *bowtieListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Discover existing objects on the Controller, for example to bring a hand-configured organization under management with `terraform query`.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list objects with exactly this name.",
+			},
+			"name_contains": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list objects whose name contains this value, ignoring case.",
+			},
+		},
+	}
+}
+
+func (l *bowtieListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T, please report this to the provider.", req.ProviderData),
+		)
+		return
+	}
+
+	l.client = client
+}
+
+func (l *bowtieListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config listConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	objects, err := l.list(ctx, l.client)
+	if err != nil {
+		diags.AddError(
+			"Failed to list objects",
+			fmt.Sprintf("Unexpected error listing %s objects: %s", strings.TrimPrefix(l.typeName, "_"), err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	objects = filterObjects(objects, config)
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, object := range objects {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = object.Name
+			for attribute, value := range object.Identity {
+				result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(attribute), value)...)
+			}
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				l.readResource(ctx, object, &result)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readResource fills in the result's resource the way `terraform import`
+// would: the managed resource imports the object's ID and then reads it.
+func (l *bowtieListResource) readResource(ctx context.Context, object listedObject, result *list.ListResult) {
+	r := l.newResource()
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		configureResp := resource.ConfigureResponse{}
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: l.client}, &configureResp)
+		result.Diagnostics.Append(configureResp.Diagnostics...)
+	}
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok || result.Diagnostics.HasError() {
+		return
+	}
+
+	importResp := resource.ImportStateResponse{
+		State:    tfsdk.State{Schema: result.Resource.Schema, Raw: result.Resource.Raw.Copy()},
+		Identity: &tfsdk.ResourceIdentity{Schema: result.Identity.Schema, Raw: result.Identity.Raw.Copy()},
+	}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: object.ImportID}, &importResp)
+	result.Diagnostics.Append(importResp.Diagnostics...)
+	if result.Diagnostics.HasError() {
+		return
+	}
+
+	readResp := resource.ReadResponse{
+		State:    tfsdk.State{Schema: importResp.State.Schema, Raw: importResp.State.Raw.Copy()},
+		Identity: importResp.Identity,
+	}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	result.Diagnostics.Append(readResp.Diagnostics...)
+	if result.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		return
+	}
+
+	result.Resource.Raw = readResp.State.Raw
+	result.Identity = readResp.Identity
+}
+
+// filterObjects applies the name filters and orders the remaining objects by
+// name, then import ID, so that results are stable between runs.
+func filterObjects(objects []listedObject, config listConfigModel) []listedObject {
+	var filtered []listedObject
+	for _, object := range objects {
+		if !config.Name.IsNull() && object.Name != config.Name.ValueString() {
+			continue
+		}
+		if !config.NameContains.IsNull() && !strings.Contains(strings.ToLower(object.Name), strings.ToLower(config.NameContains.ValueString())) {
+			continue
+		}
+		filtered = append(filtered, object)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].Name != filtered[j].Name {
+			return filtered[i].Name < filtered[j].Name
+		}
+		return filtered[i].ImportID < filtered[j].ImportID
+	})
+	return filtered
+}
+
+// byID describes an object whose identity is its ID alone.
+func byID(id, name string) listedObject {
+	return listedObject{
+		Name:     name,
+		ImportID: id,
+		Identity: map[string]string{"id": id},
+	}
+}
@@ -0,0 +1,198 @@
+package list_resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stubResource stands in for a managed resource: import sets the ID and read
+// fills in the name from names, removing objects it does not know.
+type stubResource struct {
+	names map[string]string
+}
+
+func (s *stubResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "bowtie_stub"
+}
+
+func (s *stubResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Required: true},
+		},
+	}
+}
+
+func (s *stubResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{RequiredForImport: true},
+		},
+	}
+}
+
+func (s *stubResource) Create(context.Context, resource.CreateRequest, *resource.CreateResponse) {}
+func (s *stubResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {}
+func (s *stubResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {}
+
+func (s *stubResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	name, ok := s.names[id.ValueString()]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func (s *stubResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+func stubListResource(objects []listedObject, names map[string]string) *bowtieListResource {
+	return &bowtieListResource{
+		typeName:    "_stub",
+		newResource: func() resource.Resource { return &stubResource{names: names} },
+		list: func(context.Context, *client.Client) ([]listedObject, error) {
+			if objects == nil {
+				return nil, errors.New("controller unavailable")
+			}
+			return objects, nil
+		},
+	}
+}
+
+func listRequest(t *testing.T, l *bowtieListResource, name, nameContains *string, includeResource bool, limit int64) list.ListRequest {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := list.ListResourceSchemaResponse{}
+	l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &schemaResp)
+	configType := schemaResp.Schema.Type().TerraformType(ctx)
+	config := tftypes.NewValue(configType, map[string]tftypes.Value{
+		"name":          tftypes.NewValue(tftypes.String, name),
+		"name_contains": tftypes.NewValue(tftypes.String, nameContains),
+	})
+
+	r := l.newResource().(*stubResource)
+	resourceSchema := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	identitySchema := resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+
+	return list.ListRequest{
+		Config:                 tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}
+}
+
+func collect(t *testing.T, l *bowtieListResource, req list.ListRequest) []list.ListResult {
+	t.Helper()
+	stream := list.ListResultsStream{}
+	l.List(context.Background(), req, &stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+	return results
+}
+
+func identityID(t *testing.T, result list.ListResult) string {
+	t.Helper()
+	var id types.String
+	if diags := result.Identity.GetAttribute(context.Background(), path.Root("id"), &id); diags.HasError() {
+		t.Fatalf("reading identity: %v", diags)
+	}
+	return id.ValueString()
+}
+
+func TestListFiltersAndOrdersByName(t *testing.T) {
+	objects := []listedObject{
+		byID("3", "Staging"),
+		byID("1", "Production"),
+		byID("2", "production-eu"),
+		byID("4", "Office"),
+	}
+	l := stubListResource(objects, nil)
+	contains := "PRODUCTION"
+
+	results := collect(t, l, listRequest(t, l, nil, &contains, false, 0))
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for i, want := range []string{"1", "2"} {
+		if results[i].Diagnostics.HasError() {
+			t.Fatalf("result %d: %v", i, results[i].Diagnostics)
+		}
+		if got := identityID(t, results[i]); got != want {
+			t.Errorf("result %d identity = %q, want %q", i, got, want)
+		}
+	}
+	if results[0].DisplayName != "Production" {
+		t.Errorf("display name = %q, want Production", results[0].DisplayName)
+	}
+
+	exact := "Office"
+	results = collect(t, l, listRequest(t, l, &exact, nil, false, 0))
+	if len(results) != 1 || identityID(t, results[0]) != "4" {
+		t.Fatalf("exact name filter returned %d results", len(results))
+	}
+}
+
+func TestListHonorsLimit(t *testing.T) {
+	l := stubListResource([]listedObject{byID("a", "A"), byID("b", "B"), byID("c", "C")}, nil)
+
+	results := collect(t, l, listRequest(t, l, nil, nil, false, 2))
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+}
+
+func TestListIncludesImportedResource(t *testing.T) {
+	names := map[string]string{"1": "Production"}
+	l := stubListResource([]listedObject{byID("1", "Production"), byID("2", "Deleted")}, names)
+
+	results := collect(t, l, listRequest(t, l, nil, nil, true, 0))
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	var name types.String
+	if diags := results[1].Resource.GetAttribute(context.Background(), path.Root("name"), &name); diags.HasError() {
+		t.Fatalf("reading resource: %v", diags)
+	}
+	if name.ValueString() != "Production" {
+		t.Errorf("resource name = %q, want Production", name.ValueString())
+	}
+
+	// The object that vanished between listing and reading has no resource.
+	if !results[0].Resource.Raw.IsNull() {
+		t.Errorf("expected no resource for a vanished object, got %s", results[0].Resource.Raw)
+	}
+}
+
+func TestListReportsErrors(t *testing.T) {
+	l := stubListResource(nil, nil)
+
+	results := collect(t, l, listRequest(t, l, nil, nil, false, 0))
+	if len(results) != 1 || !results[0].Diagnostics.HasError() {
+		t.Fatalf("expected a single error result, got %+v", results)
+	}
+}
@@ -0,0 +1,378 @@
+package list_resources
+
+import (
+	"context"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/resources"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+func NewUserListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_user",
+		newResource: resources.NewUserResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			users, err := c.GetUsers(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, user := range users {
+				// Users invited by email alone have no name yet.
+				name := user.Name
+				if name == "" {
+					name = user.Email
+				}
+				objects = append(objects, byID(id, name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewGroupListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_group",
+		newResource: resources.NewGroupResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			groups, err := c.GetGroups(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, group := range groups {
+				objects = append(objects, byID(id, group.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+// NewGroupMembershipListResource lists the membership of every group, named
+// after the group it belongs to.
+func NewGroupMembershipListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_group_membership",
+		newResource: resources.NewGroupMembershipResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			groups, err := c.GetGroups(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, group := range groups {
+				objects = append(objects, listedObject{
+					Name:     group.Name,
+					ImportID: id,
+					Identity: map[string]string{"group_id": id},
+				})
+			}
+			return objects, nil
+		},
+	}
+}
+
+// NewDeviceListResource lists the organization's enrolled devices, shown and
+// filtered by their name, or by their serial until they report a name.
+func NewDeviceListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_device",
+		newResource: resources.NewDeviceResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			devices, err := c.ListDevices(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, device := range devices {
+				// Devices that have not reported a name yet go by serial.
+				name := device.Name
+				if name == "" {
+					name = device.Serial
+				}
+				objects = append(objects, byID(id, name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewDeviceGroupListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_device_group",
+		newResource: resources.NewDeviceGroupResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			groups, err := c.GetDeviceGroups(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, group := range groups {
+				objects = append(objects, byID(id, group.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+// NewDeviceGroupMembershipListResource lists the membership of every device
+// group, named after the group it belongs to.
+func NewDeviceGroupMembershipListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_device_group_membership",
+		newResource: resources.NewDeviceGroupMembershipResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			groups, err := c.GetDeviceGroups(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, group := range groups {
+				objects = append(objects, listedObject{
+					Name:     group.Name,
+					ImportID: id,
+					Identity: map[string]string{"group_id": id},
+				})
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewCollectionListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_collection",
+		newResource: resources.NewCollectionResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			collections, err := c.GetCollections(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, collection := range collections {
+				objects = append(objects, byID(id, collection.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewRouteExclusionListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_route_exclusion",
+		newResource: resources.NewRouteExclusionResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			exclusions, err := c.GetRouteExclusions(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, exclusion := range exclusions {
+				objects = append(objects, byID(id, exclusion.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+// NewControllerListResource lists the organization's Controllers. They have
+// no name, so they are shown and filtered by their public address.
+func NewControllerListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_controller",
+		newResource: resources.NewControllerResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			controllers, err := c.ListControllers(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for _, controller := range controllers {
+				objects = append(objects, byID(controller.ID, controller.PublicAddress))
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewDNSListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_dns",
+		newResource: resources.NewDNSResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			dns, err := c.GetDNS(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, zone := range dns {
+				objects = append(objects, byID(id, zone.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewDNSBlockListListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_dns_block_list",
+		newResource: resources.NewDNSBlockListResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			blockLists, err := c.GetDNSBlockLists(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, blockList := range blockLists {
+				objects = append(objects, byID(id, blockList.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+// NewPolicyListResource lists the organization's policies. They have no
+// name, so they are shown and filtered by the name of the resource group
+// they grant access to.
+func NewPolicyListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_policy",
+		newResource: resources.NewPolicyResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			policies, err := c.GetPoliciesAndResources(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, policy := range policies.Policies {
+				name := policy.Dest
+				if group, ok := policies.ResourceGroups[policy.Dest]; ok {
+					name = group.Name
+				}
+				objects = append(objects, byID(id, name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewResourceListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_resource",
+		newResource: resources.NewResourceResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			policies, err := c.GetPoliciesAndResources(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, resource := range policies.Resources {
+				objects = append(objects, byID(id, resource.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewResourceGroupListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_resource_group",
+		newResource: resources.NewResourceGroupResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			groups, err := c.GetResourceGroups(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for id, group := range groups {
+				objects = append(objects, byID(id, group.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+func NewSiteListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_site",
+		newResource: resources.NewSiteResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			sites, err := c.GetSites(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for _, site := range sites {
+				objects = append(objects, byID(site.ID, site.Name))
+			}
+			return objects, nil
+		},
+	}
+}
+
+// NewSiteRangeListResource lists the ranges routed through every site, shown
+// and filtered by the name of the range.
+func NewSiteRangeListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_site_range",
+		newResource: resources.NewSiteRangeResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			sites, err := c.GetSites(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for _, site := range sites {
+				for _, r := range append(site.RoutableRangesV4, site.RouteRangesV6...) {
+					objects = append(objects, listedObject{
+						Name:     r.Name,
+						ImportID: site.ID + ":" + r.ID,
+						Identity: map[string]string{"site_id": site.ID, "id": r.ID},
+					})
+				}
+			}
+			return objects, nil
+		},
+	}
+}
+
+// NewIPv4RangeListResource lists the organization's IPv4 pools, shown and
+// filtered by their range.
+func NewIPv4RangeListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_ipv4_range",
+		newResource: resources.NewIPv4RangeResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			ranges, err := c.ListIPv4Ranges(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for _, r := range ranges {
+				objects = append(objects, byID(r.ID, r.Range))
+			}
+			return objects, nil
+		},
+	}
+}
+
+// NewIPv6RangeListResource lists the organization's IPv6 pools, shown and
+// filtered by their range.
+func NewIPv6RangeListResource() list.ListResource {
+	return &bowtieListResource{
+		typeName:    "_ipv6_range",
+		newResource: resources.NewIPv6RangeResource,
+		list: func(ctx context.Context, c *client.Client) ([]listedObject, error) {
+			ranges, err := c.ListIPv6Ranges(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var objects []listedObject
+			for _, r := range ranges {
+				objects = append(objects, byID(r.ID, r.Range))
+			}
+			return objects, nil
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// administratorRoles are granted every permission regardless of their authz
+// flags.
+var administratorRoles = map[string]bool{
+	"Owner":             true,
+	"FullAdministrator": true,
+}
+
+// permissionRequirements lists, for each authz flag, the resources whose
+// operations the Controller rejects with a 403 for an account without it.
+var permissionRequirements = []struct {
+	flag      string
+	granted   func(client.User) bool
+	resources []string
+}{
+	{
+		flag:    "authz_policies",
+		granted: func(u client.User) bool { return u.AuthZPolicies },
+		resources: []string{
+			"bowtie_collection",
+			"bowtie_policy",
+			"bowtie_resource",
+			"bowtie_resource_group",
+			"bowtie_route_exclusion",
+		},
+	},
+	{
+		flag:    "authz_users",
+		granted: func(u client.User) bool { return u.AuthZUsers },
+		resources: []string{
+			"bowtie_group",
+			"bowtie_group_membership",
+			"bowtie_user",
+		},
+	},
+	{
+		flag:    "authz_devices",
+		granted: func(u client.User) bool { return u.AuthZDevices },
+		resources: []string{
+			"bowtie_device",
+			"bowtie_device_group",
+			"bowtie_device_group_membership",
+		},
+	},
+	{
+		flag:    "authz_control_plane",
+		granted: func(u client.User) bool { return u.AuthZControlPlane },
+		resources: []string{
+			"bowtie_controller",
+			"bowtie_dns",
+			"bowtie_dns_block_list",
+			"bowtie_ipv4_range",
+			"bowtie_ipv6_range",
+			"bowtie_org_config",
+			"bowtie_organization",
+			"bowtie_site",
+			"bowtie_site_range",
+		},
+	},
+}
+
+// permissionWarnings warns about every authz flag the account lacks, so that
+// a least-privilege service account learns which resources it cannot manage
+// before an apply fails halfway through.
+func permissionWarnings(user client.User) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if administratorRoles[user.Role] {
+		return diags
+	}
+
+	for _, requirement := range permissionRequirements {
+		if requirement.granted(user) {
+			continue
+		}
+		diags.AddWarning(
+			fmt.Sprintf("Bowtie account lacks %s", requirement.flag),
+			fmt.Sprintf("The account %s (role %q) lacks %s; %s operations will fail. Grant the permission to the account or leave these resources out of the configuration.",
+				user.Email, user.Role, requirement.flag, strings.Join(requirement.resources, ", ")),
+		)
+	}
+	return diags
+}
+
+// verifyIdentity reads the account the client is authenticated as. Rejected
+// credentials are an error; otherwise the account's identity is logged and any
+// missing permissions are reported as warnings.
+func verifyIdentity(ctx context.Context, c *client.Client, diags *diag.Diagnostics) {
+	me, err := c.WhoAmI(ctx)
+	if err != nil {
+		if errors.Is(err, client.ErrUnauthorized) {
+			diags.AddError(
+				"Invalid Bowtie API Credentials",
+				"The Controller rejected the provider's credentials: "+err.Error(),
+			)
+			return
+		}
+		diags.AddWarning(
+			"Could not verify the Bowtie account's permissions",
+			"The provider could not read the account it is authenticated as: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Authenticated to the Bowtie Controller", map[string]interface{}{
+		"email": me.User.Email,
+		"role":  me.User.Role,
+	})
+	diags.Append(permissionWarnings(me.User)...)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestPermissionWarningsNameMissingFlags(t *testing.T) {
+	diags := permissionWarnings(client.User{
+		Email:             "terraform@example.com",
+		Role:              "LimitedAdministrator",
+		AuthZPolicies:     false,
+		AuthZUsers:        true,
+		AuthZDevices:      true,
+		AuthZControlPlane: true,
+	})
+
+	if len(diags) != 1 {
+		t.Fatalf("expected one warning, got %d: %v", len(diags), diags)
+	}
+	if !strings.Contains(diags[0].Summary(), "authz_policies") {
+		t.Errorf("summary %q does not name the missing flag", diags[0].Summary())
+	}
+	if !strings.Contains(diags[0].Detail(), "bowtie_policy") {
+		t.Errorf("detail %q does not name the affected resources", diags[0].Detail())
+	}
+}
+
+func TestPermissionWarningsSkipAdministrators(t *testing.T) {
+	if diags := permissionWarnings(client.User{Role: "Owner"}); len(diags) != 0 {
+		t.Errorf("expected no warnings for an owner, got %v", diags)
+	}
+}
+
+func TestPermissionRequirementsCoverEveryResource(t *testing.T) {
+	ctx := context.Background()
+	registered := map[string]bool{}
+	for _, newResource := range (&BowtieProvider{}).Resources(ctx) {
+		var resp resource.MetadataResponse
+		newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "bowtie"}, &resp)
+		registered[resp.TypeName] = true
+	}
+
+	listed := map[string]string{}
+	for _, requirement := range permissionRequirements {
+		for _, typeName := range requirement.resources {
+			if flag, ok := listed[typeName]; ok {
+				t.Errorf("%s is listed under both %s and %s", typeName, flag, requirement.flag)
+			}
+			listed[typeName] = requirement.flag
+			if !registered[typeName] {
+				t.Errorf("%s under %s is not a resource of the provider", typeName, requirement.flag)
+			}
+		}
+	}
+	for typeName := range registered {
+		if _, ok := listed[typeName]; !ok {
+			t.Errorf("%s is missing from permissionRequirements", typeName)
+		}
+	}
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,16 +22,26 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &collectionResource{}
 var _ resource.ResourceWithImportState = &collectionResource{}
+var _ resource.ResourceWithIdentity = &collectionResource{}
+
+// collectionIdentity lists the attributes that identify a collection on import
+// in place of its ID.
+var collectionIdentity = []identityAttribute{
+	{name: "name", description: "The name of the collection."},
+}
 
 type collectionResource struct {
 	client *client.Client
 }
 
 type collectionResourceModel struct {
-	ID          types.String            `tfsdk:"id"`
-	Name        types.String            `tfsdk:"name"`
-	Description types.String            `tfsdk:"description"`
-	Members     []collectionMemberModel `tfsdk:"members"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Source      types.String   `tfsdk:"source"`
+	SourceID    types.String   `tfsdk:"source_id"`
+	Members     types.Set      `tfsdk:"members"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 type collectionMemberModel struct {
@@ -73,9 +85,33 @@ func (c *collectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "An optional description of the collection.",
 				Optional:            true,
 			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "An upstream feed, such as the IP ranges a SaaS vendor publishes, that the Controller syncs the members of this collection from. The members of a collection with a source are read-only. Changing the source replaces the collection.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("members")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "Which of the lists published by `source` to sync. Changing it replaces the collection.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("source")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"members": schema.SetNestedAttribute{
-				MarkdownDescription: "The locations contained in this collection.",
+				MarkdownDescription: "The locations contained in this collection. The Controller manages the members of a collection with a `source`, so they cannot be configured and are only read back.",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					sourcedMembersModifier{},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
@@ -144,6 +180,14 @@ func (c *collectionResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -171,27 +215,39 @@ func (c *collectionResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	if plan.ID.ValueString() == "" {
-		plan.ID = types.StringValue(uuid.NewString())
-	}
-
-	members, diags := membersToAPI(plan.Members)
-	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := c.client.UpsertCollection(plan.ID.ValueString(), plan.Name.ValueString(), descriptionString(plan.Description)); err != nil {
+	if plan.ID.ValueString() == "" {
+		plan.ID = types.StringValue(uuid.NewString())
+	}
+
+	if err := c.client.UpsertCollection(ctx, plan.ID.ValueString(), plan.Name.ValueString(), descriptionString(plan.Description), plan.Source.ValueStringPointer(), plan.SourceID.ValueStringPointer()); err != nil {
 		resp.Diagnostics.AddError("Failed to create collection", "Unexpected error creating collection: "+err.Error())
 		return
 	}
 
-	if err := c.client.AddCollectionMembers(plan.ID.ValueString(), members); err != nil {
-		resp.Diagnostics.AddError("Failed to add collection members", "Unexpected error adding collection members: "+err.Error())
+	if isSet(plan.Source) {
+		plan.Members = c.readSourcedMembers(ctx, plan.ID.ValueString(), plan.Members.ElementType(ctx), &resp.Diagnostics)
+	} else {
+		members := membersFromPlan(ctx, plan.Members, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := c.client.AddCollectionMembers(ctx, plan.ID.ValueString(), members); err != nil {
+			resp.Diagnostics.AddError("Failed to add collection members", "Unexpected error adding collection members: "+err.Error())
+			return
+		}
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (c *collectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -200,10 +256,17 @@ func (c *collectionResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	collections, err := c.client.GetCollections()
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collections, err := c.client.GetCollections(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read collection", "Unexpected error reading collection "+state.ID.ValueString()+": "+err.Error())
+		resp.Diagnostics.AddError("Failed to read collection", "Unexpected error reading collection "+state.ID.ValueString()+": "+apiErrorDetail(err))
 		return
 	}
 
@@ -225,12 +288,12 @@ func (c *collectionResource) Read(ctx context.Context, req resource.ReadRequest,
 		state.Description = types.StringNull()
 	}
 
-	members, diags := membersFromAPI(collection.Members)
-	resp.Diagnostics.Append(diags...)
+	state.Source = stringFromPtr(collection.Source)
+	state.SourceID = stringFromPtr(collection.SourceID)
+	state.Members = membersValue(ctx, state.Members.ElementType(ctx), collection.Members, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	state.Members = members
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -242,38 +305,54 @@ func (c *collectionResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	desired, diags := membersToAPI(plan.Members)
-	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := c.client.UpsertCollection(plan.ID.ValueString(), plan.Name.ValueString(), descriptionString(plan.Description)); err != nil {
+	if err := c.client.UpsertCollection(ctx, plan.ID.ValueString(), plan.Name.ValueString(), descriptionString(plan.Description), plan.Source.ValueStringPointer(), plan.SourceID.ValueStringPointer()); err != nil {
 		resp.Diagnostics.AddError("Failed to update collection", "Unexpected error updating collection "+plan.ID.ValueString()+": "+err.Error())
 		return
 	}
 
+	if isSet(plan.Source) {
+		plan.Members = c.readSourcedMembers(ctx, plan.ID.ValueString(), plan.Members.ElementType(ctx), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+		return
+	}
+
+	desired := membersFromPlan(ctx, plan.Members, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Members can only be mutated through add/remove, so replace the full set:
 	// drop everything currently stored, then add the desired members back.
-	collections, err := c.client.GetCollections()
+	collections, err := c.client.GetCollections(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read collection members", "Unexpected error reading collection "+plan.ID.ValueString()+": "+err.Error())
 		return
 	}
 	if current, present := collections[plan.ID.ValueString()]; present {
 		existingIDs := collectionMemberIDs(current.Members)
-		if err := c.client.RemoveCollectionMembers(plan.ID.ValueString(), existingIDs); err != nil {
+		if err := c.client.RemoveCollectionMembers(ctx, plan.ID.ValueString(), existingIDs); err != nil {
 			resp.Diagnostics.AddError("Failed to remove collection members", "Unexpected error removing collection members: "+err.Error())
 			return
 		}
 	}
 
-	if err := c.client.AddCollectionMembers(plan.ID.ValueString(), desired); err != nil {
+	if err := c.client.AddCollectionMembers(ctx, plan.ID.ValueString(), desired); err != nil {
 		resp.Diagnostics.AddError("Failed to add collection members", "Unexpected error adding collection members: "+err.Error())
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (c *collectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -283,13 +362,112 @@ func (c *collectionResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	if err := c.client.DeleteCollection(state.ID.ValueString()); err != nil {
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := c.client.DeleteCollection(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Failed to delete collection", "Unexpected error deleting collection "+state.ID.ValueString()+": "+err.Error())
 	}
 }
 
+func (c *collectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	naturalIdentitySchema(resp, collectionIdentity...)
+}
+
 func (c *collectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIdentity(ctx, req, resp, collectionIdentity, func(ctx context.Context, values map[string]string) (string, error) {
+		collections, err := c.client.GetCollections(ctx)
+		if err != nil {
+			return "", err
+		}
+		names := map[string]string{}
+		for id, collection := range collections {
+			names[id] = collection.Name
+		}
+		return matchOne("collection", "name", values["name"], names)
+	})
+}
+
+// sourcedMembersModifier plans the members of a collection synced from a
+// source, which the Controller owns: they keep their last read value, and are
+// unknown until read after the collection is created or replaced. A static
+// collection that configures no members has none.
+type sourcedMembersModifier struct{}
+
+func (m sourcedMembersModifier) Description(ctx context.Context) string {
+	return "Keeps the members the Controller synced into a collection with a source."
+}
+
+func (m sourcedMembersModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m sourcedMembersModifier) PlanModifySet(ctx context.Context, req planmodifier.SetRequest, resp *planmodifier.SetResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var source, sourceID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source"), &source)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source_id"), &sourceID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if source.IsNull() {
+		resp.PlanValue = types.SetNull(req.PlanValue.ElementType(ctx))
+		return
+	}
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var priorSource, priorSourceID types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source"), &priorSource)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source_id"), &priorSourceID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if source.Equal(priorSource) && sourceID.Equal(priorSourceID) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// readSourcedMembers reads back the members the Controller synced into the
+// collection id from its source.
+func (c *collectionResource) readSourcedMembers(ctx context.Context, id string, elemType attr.Type, diags *diag.Diagnostics) types.Set {
+	collections, err := c.client.GetCollections(ctx)
+	if err != nil {
+		diags.AddError("Failed to read collection members", "Unexpected error reading collection "+id+": "+apiErrorDetail(err))
+		return types.SetNull(elemType)
+	}
+	return membersValue(ctx, elemType, collections[id].Members, diags)
+}
+
+func membersFromPlan(ctx context.Context, value types.Set, diags *diag.Diagnostics) []client.BowtieCollectionMember {
+	var members []collectionMemberModel
+	diags.Append(value.ElementsAs(ctx, &members, false)...)
+	if diags.HasError() {
+		return nil
+	}
+	out, memberDiags := membersToAPI(members)
+	diags.Append(memberDiags...)
+	return out
+}
+
+// membersValue is the members attribute holding members, null when there are
+// none so that it matches a configuration that simply omits members.
+func membersValue(ctx context.Context, elemType attr.Type, members map[string]client.BowtieCollectionMember, diags *diag.Diagnostics) types.Set {
+	models, modelDiags := membersFromAPI(members)
+	diags.Append(modelDiags...)
+	if diags.HasError() || models == nil {
+		return types.SetNull(elemType)
+	}
+	value, valueDiags := types.SetValueFrom(ctx, elemType, models)
+	diags.Append(valueDiags...)
+	return value
 }
 
 func membersToAPI(members []collectionMemberModel) ([]client.BowtieCollectionMember, diag.Diagnostics) {
@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceValue is an object of schema s with the given attributes set and
+// all others null.
+func resourceValue(ctx context.Context, s schema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func TestSourcedMembersModifier(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := resource.SchemaResponse{}
+	(&collectionResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	membersType := s.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["members"]
+	memberType := membersType.(tftypes.Set).ElementType.(tftypes.Object)
+	locationType := memberType.AttributeTypes["location"].(tftypes.Object)
+	synced := tftypes.NewValue(membersType, []tftypes.Value{
+		tftypes.NewValue(memberType, map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, "203.0.113.0/24"),
+			"comment": tftypes.NewValue(tftypes.String, nil),
+			"expires": tftypes.NewValue(tftypes.String, nil),
+			"location": tftypes.NewValue(locationType, map[string]tftypes.Value{
+				"ip":         tftypes.NewValue(tftypes.String, nil),
+				"cidr":       tftypes.NewValue(tftypes.String, "203.0.113.0/24"),
+				"dns":        tftypes.NewValue(tftypes.String, nil),
+				"collection": tftypes.NewValue(tftypes.String, nil),
+			}),
+		}),
+	})
+	unknown := tftypes.NewValue(membersType, tftypes.UnknownValue)
+	str := func(value string) tftypes.Value { return tftypes.NewValue(tftypes.String, value) }
+
+	for name, tc := range map[string]struct {
+		plan  map[string]tftypes.Value
+		state map[string]tftypes.Value
+		want  tftypes.Value
+	}{
+		"static collection without members": {
+			plan: map[string]tftypes.Value{"members": unknown},
+			want: tftypes.NewValue(membersType, nil),
+		},
+		"new sourced collection": {
+			plan: map[string]tftypes.Value{"source": str("aws"), "members": unknown},
+			want: unknown,
+		},
+		"unchanged sourced collection": {
+			plan:  map[string]tftypes.Value{"source": str("aws"), "source_id": str("us-east-1"), "members": unknown},
+			state: map[string]tftypes.Value{"source": str("aws"), "source_id": str("us-east-1"), "members": synced},
+			want:  synced,
+		},
+		"replaced sourced collection": {
+			plan:  map[string]tftypes.Value{"source": str("aws"), "source_id": str("eu-west-1"), "members": unknown},
+			state: map[string]tftypes.Value{"source": str("aws"), "source_id": str("us-east-1"), "members": synced},
+			want:  unknown,
+		},
+	} {
+		plan := tfsdk.Plan{Schema: s, Raw: resourceValue(ctx, s, tc.plan)}
+		var planValue types.Set
+		plan.GetAttribute(ctx, path.Root("members"), &planValue)
+
+		state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		stateValue := types.SetNull(planValue.ElementType(ctx))
+		if tc.state != nil {
+			state.Raw = resourceValue(ctx, s, tc.state)
+			state.GetAttribute(ctx, path.Root("members"), &stateValue)
+		}
+
+		req := planmodifier.SetRequest{
+			Path:        path.Root("members"),
+			Plan:        plan,
+			PlanValue:   planValue,
+			State:       state,
+			StateValue:  stateValue,
+			ConfigValue: types.SetNull(planValue.ElementType(ctx)),
+		}
+		resp := planmodifier.SetResponse{PlanValue: planValue}
+		sourcedMembersModifier{}.PlanModifySet(ctx, req, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: %v", name, resp.Diagnostics)
+		}
+
+		got, err := resp.PlanValue.ToTerraformValue(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !got.Equal(tc.want) {
+			t.Errorf("%s: planned members %s, want %s", name, got, tc.want)
+		}
+	}
+}
@@ -6,54 +6,70 @@ import (
 	"time"
 
 	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 var _ resource.Resource = &controllerResource{}
 var _ resource.ResourceWithImportState = &controllerResource{}
 var _ resource.ResourceWithValidateConfig = &controllerResource{}
+var _ resource.ResourceWithModifyPlan = &controllerResource{}
+var _ resource.ResourceWithIdentity = &controllerResource{}
+
+// controllerIdentity lists the attributes that identify a controller on import
+// in place of its ID.
+var controllerIdentity = []identityAttribute{
+	{name: "public_address", description: "The public address the Controller is reachable at."},
+}
 
 type controllerResource struct {
 	client *client.Client
 }
 
 type controllerResourceModel struct {
-	ID                              types.String `tfsdk:"id"`
-	LastUpdated                     types.String `tfsdk:"last_updated"`
-	ClearOverrides                  types.Set    `tfsdk:"clear_overrides"`
-	SiteID                          types.String `tfsdk:"site_id"`
-	PublicAddress                   types.String `tfsdk:"public_address"`
-	WireguardPort                   types.Int64  `tfsdk:"wireguard_port"`
-	PersistentKeepalive             types.Int64  `tfsdk:"persistent_keepalive"`
-	WireguardStrategy               types.String `tfsdk:"wireguard_strategy"`
-	VersionStrategyType             types.String `tfsdk:"version_strategy_type"`
-	VersionStrategyValue            types.String `tfsdk:"version_strategy_value"`
-	VersionStrategySplayType        types.String `tfsdk:"version_strategy_splay_type"`
-	VersionStrategySplayValue       types.String `tfsdk:"version_strategy_splay_value"`
-	VersionIncludePrereleases       types.Bool   `tfsdk:"version_include_prereleases"`
-	VersionMinimumAge               types.Int64  `tfsdk:"version_minimum_age"`
-	SSHListener                     types.String `tfsdk:"ssh_listener"`
-	WebFilterTrustedProxyCollection types.String `tfsdk:"web_filter_trusted_proxy_collection"`
-	CanUseVanityDomain              types.Bool   `tfsdk:"can_use_vanity_domain"`
-	CanUsePublicHTTPS               types.Bool   `tfsdk:"can_use_public_https"`
-	CanUseIDP                       types.Bool   `tfsdk:"can_use_idp"`
-	TrackPolicyVerdictMetrics       types.Bool   `tfsdk:"track_policy_verdict_metrics"`
-	TrackPolicyVerdictLogs          types.Bool   `tfsdk:"track_policy_verdict_logs"`
-	AllowTemporaryConsoleUsers      types.Bool   `tfsdk:"allow_temporary_console_users"`
-	PublicKey                       types.String `tfsdk:"public_key"`
-	HTTPSEndpoint                   types.String `tfsdk:"https_endpoint"`
-	CurrentVersion                  types.String `tfsdk:"current_version"`
+	ID                              types.String   `tfsdk:"id"`
+	LastUpdated                     types.String   `tfsdk:"last_updated"`
+	ClearOverrides                  types.Set      `tfsdk:"clear_overrides"`
+	SiteID                          types.String   `tfsdk:"site_id"`
+	PublicAddress                   types.String   `tfsdk:"public_address"`
+	WireguardPort                   types.Int64    `tfsdk:"wireguard_port"`
+	PersistentKeepalive             types.Int64    `tfsdk:"persistent_keepalive"`
+	WireguardStrategy               types.String   `tfsdk:"wireguard_strategy"`
+	VersionStrategyType             types.String   `tfsdk:"version_strategy_type"`
+	VersionStrategyValue            types.String   `tfsdk:"version_strategy_value"`
+	VersionStrategySplayType        types.String   `tfsdk:"version_strategy_splay_type"`
+	VersionStrategySplayValue       types.String   `tfsdk:"version_strategy_splay_value"`
+	VersionIncludePrereleases       types.Bool     `tfsdk:"version_include_prereleases"`
+	VersionMinimumAge               types.Int64    `tfsdk:"version_minimum_age"`
+	SSHListener                     types.String   `tfsdk:"ssh_listener"`
+	WebFilterTrustedProxyCollection types.String   `tfsdk:"web_filter_trusted_proxy_collection"`
+	BackupStrategies                types.List     `tfsdk:"backup_strategies"`
+	MinimumPeersBehavior            types.Object   `tfsdk:"minimum_peers_behavior"`
+	CanUseVanityDomain              types.Bool     `tfsdk:"can_use_vanity_domain"`
+	CanUsePublicHTTPS               types.Bool     `tfsdk:"can_use_public_https"`
+	CanUseIDP                       types.Bool     `tfsdk:"can_use_idp"`
+	TrackPolicyVerdictMetrics       types.Bool     `tfsdk:"track_policy_verdict_metrics"`
+	TrackPolicyVerdictLogs          types.Bool     `tfsdk:"track_policy_verdict_logs"`
+	AllowTemporaryConsoleUsers      types.Bool     `tfsdk:"allow_temporary_console_users"`
+	PublicKey                       types.String   `tfsdk:"public_key"`
+	HTTPSEndpoint                   types.String   `tfsdk:"https_endpoint"`
+	CurrentVersion                  types.String   `tfsdk:"current_version"`
+	Timeouts                        timeouts.Value `tfsdk:"timeouts"`
 }
 
 var controllerClearableOverrides = map[string]struct{}{
@@ -62,6 +78,51 @@ var controllerClearableOverrides = map[string]struct{}{
 	"version_minimum_age":                 {},
 	"ssh_listener":                        {},
 	"web_filter_trusted_proxy_collection": {},
+	"backup_strategies":                   {},
+	"minimum_peers_behavior":              {},
+}
+
+// controllerBackupStrategyModel is one element of backup_strategies.
+type controllerBackupStrategyModel struct {
+	Type     types.String `tfsdk:"type"`
+	Bucket   types.String `tfsdk:"bucket"`
+	Prefix   types.String `tfsdk:"prefix"`
+	Region   types.String `tfsdk:"region"`
+	Endpoint types.String `tfsdk:"endpoint"`
+	Path     types.String `tfsdk:"path"`
+}
+
+var controllerBackupStrategyAttrTypes = map[string]attr.Type{
+	"type":     types.StringType,
+	"bucket":   types.StringType,
+	"prefix":   types.StringType,
+	"region":   types.StringType,
+	"endpoint": types.StringType,
+	"path":     types.StringType,
+}
+
+// controllerBackupStrategyFields lists, per backup strategy type, the
+// attributes it uses and whether each is required.
+var controllerBackupStrategyFields = map[string]map[string]bool{
+	"s3": {
+		"bucket":   true,
+		"prefix":   false,
+		"region":   false,
+		"endpoint": false,
+	},
+	"directory": {
+		"path": true,
+	},
+}
+
+type controllerMinimumPeersModel struct {
+	Type       types.String `tfsdk:"type"`
+	Percentage types.Int64  `tfsdk:"percentage"`
+}
+
+var controllerMinimumPeersAttrTypes = map[string]attr.Type{
+	"type":       types.StringType,
+	"percentage": types.Int64Type,
 }
 
 var controllerVersionStrategyValueVariants = map[string]bool{
@@ -87,7 +148,8 @@ func (r *controllerResource) Schema(ctx context.Context, req resource.SchemaRequ
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `
 Manage the lifecycle settings of an existing Bowtie Controller: its update
-(version) strategy, update stagger, minimum release age, and other per-Controller options.
+(version) strategy, update stagger, minimum release age, backup destinations, peer quorum, and
+other per-Controller options.
 
 **Note**: Controllers register themselves with the control plane when they boot, so this
 resource **cannot create or destroy** a Controller. Create will fail; instead, import an
@@ -112,7 +174,7 @@ it in ` + "`clear_overrides`" + `.
 			"clear_overrides": schema.SetAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Per-Controller overrides to clear on update. Supported values are `version_strategy_splay`, `version_include_prereleases`, `version_minimum_age`, `ssh_listener`, and `web_filter_trusted_proxy_collection`. Use `version_strategy_type = \"org-default\"` to make the Controller inherit the organization update strategy.",
+				MarkdownDescription: "Per-Controller overrides to clear on update. Supported values are `version_strategy_splay`, `version_include_prereleases`, `version_minimum_age`, `ssh_listener`, `web_filter_trusted_proxy_collection`, `backup_strategies`, and `minimum_peers_behavior`. Use `version_strategy_type = \"org-default\"` to make the Controller inherit the organization update strategy.",
 			},
 			"site_id": schema.StringAttribute{
 				Optional:            true,
@@ -212,6 +274,65 @@ it in ` + "`clear_overrides`" + `.
 				MarkdownDescription: "A collection ID used as the web-filter trusted proxy list for this Controller.",
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
+			"backup_strategies": schema.ListNestedAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Destinations this Controller writes its backups to. Backups use the credentials of the " +
+					"Controller's host; none are stored here. An empty list turns backups off, and clearing the attribute " +
+					"with `clear_overrides` removes the per-Controller setting. Settings of a destination this resource " +
+					"does not manage, such as an S3 storage class, are kept while its `type` is unchanged.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The kind of destination: `s3` for an S3-compatible bucket or `directory` for a path on the Controller's host.",
+							Validators:          []validator.String{stringvalidator.OneOf("s3", "directory")},
+						},
+						"bucket": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The bucket to write to. Required for `s3`.",
+						},
+						"prefix": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "A key prefix for backups within the bucket. Only for `s3`.",
+						},
+						"region": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The bucket's region. Only for `s3`.",
+						},
+						"endpoint": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The URL of an S3-compatible service other than AWS. Only for `s3`.",
+						},
+						"path": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The directory to write to. Required for `directory`.",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+			},
+			"minimum_peers_behavior": schema.SingleNestedAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Overrides the organization's peer quorum (`disable_peers_require_quorum` and " +
+					"`peers_require_quorum_percentage` on `bowtie_org_config`) for this Controller. Unset falls back to the " +
+					"organization default.",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Required: true,
+						MarkdownDescription: "`disabled` lets destructive device cleanup proceed without a quorum; `percentage` " +
+							"requires `percentage` percent of the Controller's peers to be connected.",
+						Validators: []validator.String{stringvalidator.OneOf("disabled", "percentage")},
+					},
+					"percentage": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Percentage, from 0 through 100, of peers required. Required for `percentage` and not allowed for `disabled`.",
+						Validators:          []validator.Int64{int64validator.Between(0, 100)},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{objectplanmodifier.UseStateForUnknown()},
+			},
 			"can_use_vanity_domain": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -261,6 +382,12 @@ it in ` + "`clear_overrides`" + `.
 				MarkdownDescription: "The software version the Controller is currently running.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read:   true,
+				Update: true,
+			}),
+		},
 	}
 }
 
@@ -289,8 +416,15 @@ func (r *controllerResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	controller, err := r.client.GetController(state.ID.ValueString())
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	controller, err := r.client.GetController(ctx, state.ID.ValueString())
 	if err != nil {
 		if isNotFoundError(err) {
 			resp.Diagnostics.AddAttributeWarning(
@@ -301,11 +435,14 @@ func (r *controllerResource) Read(ctx context.Context, req resource.ReadRequest,
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed reading Controller", err.Error())
+		resp.Diagnostics.AddError("Failed reading Controller", apiErrorDetail(err))
 		return
 	}
 
-	r.mapToState(controller, &state)
+	r.mapToState(ctx, controller, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -316,10 +453,16 @@ func (r *controllerResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Read-modify-write: the update endpoint overlays the posted payload onto
 	// the existing record, so start from the current representation and change
 	// only the managed fields.
-	current, err := r.client.GetController(plan.ID.ValueString())
+	current, err := r.client.GetController(ctx, plan.ID.ValueString())
 	if err != nil {
 		if isNotFoundError(err) {
 			resp.Diagnostics.AddAttributeWarning(
@@ -330,7 +473,7 @@ func (r *controllerResource) Update(ctx context.Context, req resource.UpdateRequ
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed reading Controller before update", err.Error())
+		resp.Diagnostics.AddError("Failed reading Controller before update", apiErrorDetail(err))
 		return
 	}
 
@@ -373,6 +516,15 @@ func (r *controllerResource) Update(ctx context.Context, req resource.UpdateRequ
 		v := plan.WebFilterTrustedProxyCollection.ValueString()
 		current.WebFilterTrustedProxyCollection = &v
 	}
+	if isSetList(plan.BackupStrategies) {
+		current.BackupStrategies = backupStrategiesFromPlan(ctx, plan.BackupStrategies, current.BackupStrategies, &resp.Diagnostics)
+	}
+	if isSetObject(plan.MinimumPeersBehavior) {
+		current.MinimumPeersBehavior = minimumPeersFromPlan(ctx, plan.MinimumPeersBehavior, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if !plan.CanUseVanityDomain.IsNull() && !plan.CanUseVanityDomain.IsUnknown() {
 		current.CanUseVanityDomain = plan.CanUseVanityDomain.ValueBool()
 	}
@@ -413,16 +565,26 @@ func (r *controllerResource) Update(ctx context.Context, req resource.UpdateRequ
 	if clearOverrides["web_filter_trusted_proxy_collection"] {
 		current.WebFilterTrustedProxyCollection = nil
 	}
+	if clearOverrides["backup_strategies"] {
+		current.BackupStrategies = nil
+	}
+	if clearOverrides["minimum_peers_behavior"] {
+		current.MinimumPeersBehavior = nil
+	}
 
-	updated, err := r.client.UpdateController(current)
+	updated, err := r.client.UpdateController(ctx, current)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed updating Controller", err.Error())
+		resp.Diagnostics.AddError("Failed updating Controller", apiErrorDetail(err))
 		return
 	}
 
-	r.mapToState(updated, &plan)
+	r.mapToState(ctx, updated, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *controllerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -432,8 +594,22 @@ func (r *controllerResource) Delete(ctx context.Context, req resource.DeleteRequ
 	)
 }
 
+func (r *controllerResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	naturalIdentitySchema(resp, controllerIdentity...)
+}
+
 func (r *controllerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIdentity(ctx, req, resp, controllerIdentity, func(ctx context.Context, values map[string]string) (string, error) {
+		controllers, err := r.client.ListControllers(ctx)
+		if err != nil {
+			return "", err
+		}
+		addresses := map[string]string{}
+		for _, controller := range controllers {
+			addresses[controller.ID] = controller.PublicAddress
+		}
+		return matchOne("controller", "public address", values["public_address"], addresses)
+	})
 }
 
 func (r *controllerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -455,6 +631,8 @@ func (r *controllerResource) ValidateConfig(ctx context.Context, req resource.Va
 		controllerVersionStrategySplayValueVariants,
 		&resp.Diagnostics,
 	)
+	validateBackupStrategies(ctx, config.BackupStrategies, path.Root("backup_strategies"), &resp.Diagnostics)
+	validateMinimumPeers(ctx, config.MinimumPeersBehavior, path.Root("minimum_peers_behavior"), &resp.Diagnostics)
 	validateClearConflicts(
 		ctx,
 		config.ClearOverrides,
@@ -466,13 +644,69 @@ func (r *controllerResource) ValidateConfig(ctx context.Context, req resource.Va
 			"version_minimum_age":                 isSetInt(config.VersionMinimumAge),
 			"ssh_listener":                        isSet(config.SSHListener),
 			"web_filter_trusted_proxy_collection": isSet(config.WebFilterTrustedProxyCollection),
+			"backup_strategies":                   isSetList(config.BackupStrategies),
+			"minimum_peers_behavior":              isSetObject(config.MinimumPeersBehavior),
 		},
 		&resp.Diagnostics,
 	)
 }
 
+// ModifyPlan rejects configured attributes that the organization's
+// Controllers are too old to serve, which they would otherwise silently
+// ignore on update.
+func (r *controllerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var config controllerResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Capabilities logs why detection failed; the Controller then gets to
+	// judge the update itself.
+	caps, err := r.client.Capabilities(ctx)
+	if err != nil {
+		return
+	}
+
+	fields := []struct {
+		field     string
+		attribute path.Path
+		set       bool
+	}{
+		{"version_strategy_splay", path.Root("version_strategy_splay_type"), isSet(config.VersionStrategySplayType) || isSet(config.VersionStrategySplayValue)},
+		{"version_include_prereleases", path.Root("version_include_prereleases"), isSetBool(config.VersionIncludePrereleases)},
+		{"version_minimum_age", path.Root("version_minimum_age"), isSetInt(config.VersionMinimumAge)},
+		{"ssh_listener", path.Root("ssh_listener"), isSet(config.SSHListener)},
+		{"web_filter_trusted_proxy_collection", path.Root("web_filter_trusted_proxy_collection"), isSet(config.WebFilterTrustedProxyCollection)},
+		{"track_policy_verdict_metrics", path.Root("track_policy_verdict_metrics"), isSetBool(config.TrackPolicyVerdictMetrics)},
+		{"track_policy_verdict_logs", path.Root("track_policy_verdict_logs"), isSetBool(config.TrackPolicyVerdictLogs)},
+		{"allow_temporary_console_users", path.Root("allow_temporary_console_users"), isSetBool(config.AllowTemporaryConsoleUsers)},
+		{"backup_strategies", path.Root("backup_strategies"), isSetList(config.BackupStrategies)},
+		{"minimum_peers_behavior", path.Root("minimum_peers_behavior"), isSetObject(config.MinimumPeersBehavior)},
+	}
+
+	version := caps.Version
+	if version == "" {
+		version = "unknown"
+	}
+	for _, f := range fields {
+		if !f.set || caps.SupportsControllerField(f.field) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			f.attribute,
+			"Unsupported by this Controller version",
+			fmt.Sprintf("The organization's Controllers (oldest version %s) do not support %s. Upgrade every Controller or remove the attribute from the configuration.", version, f.attribute),
+		)
+	}
+}
+
 // mapToState copies the server representation into the Terraform model.
-func (r *controllerResource) mapToState(c *client.ControllerSettings, state *controllerResourceModel) {
+func (r *controllerResource) mapToState(ctx context.Context, c *client.ControllerSettings, state *controllerResourceModel, diags *diag.Diagnostics) {
 	state.ID = types.StringValue(c.ID)
 	state.SiteID = stringFromPtr(c.SiteID)
 	state.PublicAddress = types.StringValue(c.PublicAddress)
@@ -490,6 +724,8 @@ func (r *controllerResource) mapToState(c *client.ControllerSettings, state *con
 	state.VersionMinimumAge = int64FromIntPtr(c.VersionMinimumAge)
 	state.SSHListener = stringFromPtr(c.SSHListener)
 	state.WebFilterTrustedProxyCollection = stringFromPtr(c.WebFilterTrustedProxyCollection)
+	state.BackupStrategies = backupStrategiesToState(ctx, c.BackupStrategies, diags)
+	state.MinimumPeersBehavior = minimumPeersToState(ctx, c.MinimumPeersBehavior, diags)
 	state.CanUseVanityDomain = types.BoolValue(c.CanUseVanityDomain)
 	state.CanUsePublicHTTPS = types.BoolValue(c.CanUsePublicHTTPS)
 	state.CanUseIDP = types.BoolValue(c.CanUseIDP)
@@ -501,6 +737,146 @@ func (r *controllerResource) mapToState(c *client.ControllerSettings, state *con
 	state.CurrentVersion = stringFromPtr(c.CurrentVersion)
 }
 
+// backupStrategiesFromPlan converts backup_strategies to the API list. An
+// empty list stays empty rather than nil, since nil clears the override.
+// Each strategy starts from the current one at the same position when it is
+// of the same type, so members the provider does not model, such as an S3
+// storage class, are posted back unchanged.
+func backupStrategiesFromPlan(ctx context.Context, value types.List, current []client.BackupStrategy, diags *diag.Diagnostics) []client.BackupStrategy {
+	var elements []controllerBackupStrategyModel
+	diags.Append(value.ElementsAs(ctx, &elements, false)...)
+
+	strategies := make([]client.BackupStrategy, 0, len(elements))
+	for i, element := range elements {
+		var location client.BackupLocation
+		if i < len(current) && current[i].Type == element.Type.ValueString() {
+			location = current[i].Value
+		}
+		location.Bucket = optionalString(element.Bucket)
+		location.Prefix = optionalString(element.Prefix)
+		location.Region = optionalString(element.Region)
+		location.Endpoint = optionalString(element.Endpoint)
+		location.Path = optionalString(element.Path)
+
+		strategies = append(strategies, client.BackupStrategy{
+			Type:  element.Type.ValueString(),
+			Value: location,
+		})
+	}
+	return strategies
+}
+
+func backupStrategiesToState(ctx context.Context, strategies []client.BackupStrategy, diags *diag.Diagnostics) types.List {
+	elementType := types.ObjectType{AttrTypes: controllerBackupStrategyAttrTypes}
+	if strategies == nil {
+		return types.ListNull(elementType)
+	}
+
+	elements := make([]controllerBackupStrategyModel, 0, len(strategies))
+	for _, strategy := range strategies {
+		elements = append(elements, controllerBackupStrategyModel{
+			Type:     types.StringValue(strategy.Type),
+			Bucket:   stringFromPtr(strategy.Value.Bucket),
+			Prefix:   stringFromPtr(strategy.Value.Prefix),
+			Region:   stringFromPtr(strategy.Value.Region),
+			Endpoint: stringFromPtr(strategy.Value.Endpoint),
+			Path:     stringFromPtr(strategy.Value.Path),
+		})
+	}
+	list, d := types.ListValueFrom(ctx, elementType, elements)
+	diags.Append(d...)
+	return list
+}
+
+func minimumPeersFromPlan(ctx context.Context, value types.Object, diags *diag.Diagnostics) *client.MinimumPeersBehavior {
+	var model controllerMinimumPeersModel
+	diags.Append(value.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+
+	behavior := &client.MinimumPeersBehavior{Type: model.Type.ValueString()}
+	if behavior.Type == "percentage" && isSetInt(model.Percentage) {
+		percentage := int(model.Percentage.ValueInt64())
+		behavior.Value = &percentage
+	}
+	return behavior
+}
+
+func minimumPeersToState(ctx context.Context, behavior *client.MinimumPeersBehavior, diags *diag.Diagnostics) types.Object {
+	if behavior == nil {
+		return types.ObjectNull(controllerMinimumPeersAttrTypes)
+	}
+	model := controllerMinimumPeersModel{
+		Type:       types.StringValue(behavior.Type),
+		Percentage: types.Int64Null(),
+	}
+	if behavior.Type == "percentage" {
+		model.Percentage = int64FromIntPtr(behavior.Value)
+	}
+	object, d := types.ObjectValueFrom(ctx, controllerMinimumPeersAttrTypes, model)
+	diags.Append(d...)
+	return object
+}
+
+// validateBackupStrategies enforces that each backup strategy sets the
+// attributes its type requires and none that belong to another type.
+func validateBackupStrategies(ctx context.Context, value types.List, listPath path.Path, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	var elements []types.Object
+	diags.Append(value.ElementsAs(ctx, &elements, false)...)
+	for i, element := range elements {
+		if element.IsNull() || element.IsUnknown() {
+			continue
+		}
+		attributes := element.Attributes()
+		strategyType, ok := attributes["type"].(types.String)
+		if !ok || strategyType.IsNull() || strategyType.IsUnknown() {
+			continue
+		}
+		fields, known := controllerBackupStrategyFields[strategyType.ValueString()]
+		if !known {
+			continue
+		}
+		for name, attribute := range attributes {
+			if name == "type" {
+				continue
+			}
+			required, used := fields[name]
+			set := !attribute.IsNull()
+			switch {
+			case set && !used:
+				diags.AddAttributeError(listPath.AtListIndex(i).AtName(name), "Unexpected "+name,
+					fmt.Sprintf("%s must not be set for a backup strategy of type %q.", name, strategyType.ValueString()))
+			case required && !set:
+				diags.AddAttributeError(listPath.AtListIndex(i).AtName(name), "Missing "+name,
+					fmt.Sprintf("A backup strategy of type %q requires %s to be set.", strategyType.ValueString(), name))
+			}
+		}
+	}
+}
+
+// validateMinimumPeers enforces that percentage is set exactly when the
+// minimum peers behavior is of type "percentage".
+func validateMinimumPeers(ctx context.Context, value types.Object, objectPath path.Path, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	var model controllerMinimumPeersModel
+	diags.Append(value.As(ctx, &model, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+	if model.Type.IsNull() || model.Type.IsUnknown() {
+		return
+	}
+	needs := model.Type.ValueString() == "percentage"
+	if needs && model.Percentage.IsNull() {
+		diags.AddAttributeError(objectPath.AtName("percentage"), "Missing percentage",
+			`minimum_peers_behavior type "percentage" requires percentage to be set.`)
+	}
+	if !needs && !model.Percentage.IsNull() {
+		diags.AddAttributeError(objectPath.AtName("percentage"), "Unexpected percentage",
+			fmt.Sprintf("percentage must not be set when minimum_peers_behavior type is %q.", model.Type.ValueString()))
+	}
+}
+
 func optionalString(v types.String) *string {
 	if v.IsNull() || v.IsUnknown() {
 		return nil
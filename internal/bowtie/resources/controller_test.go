@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newTestClient returns a client for the Controller served by ts, sending an
+// API token so that no login is needed.
+func newTestClient(t *testing.T, ts *httptest.Server) *client.Client {
+	t.Helper()
+	c, err := client.NewClient(context.Background(), ts.URL, "", "", true, false, false, "",
+		client.WithAPIToken("test"),
+		client.WithReadCacheTTL(0),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+func TestControllerUpdateKeepsUnmodeledBackupMembers(t *testing.T) {
+	stored := `{
+		"id": "controller-1",
+		"backup_strategies": [
+			{"type": "s3", "value": {"bucket": "dr-backups", "region": "us-east-1", "storage_class": "GLACIER"}},
+			{"type": "directory", "value": {"path": "/var/backups/bowtie", "retain": 7}}
+		]
+	}`
+	var posted map[string]json.RawMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &posted); err != nil {
+				t.Errorf("decoding posted controller: %v", err)
+			}
+			_, _ = w.Write(body)
+			return
+		}
+		_, _ = w.Write([]byte(stored))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	r := &controllerResource{client: newTestClient(t, ts)}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	// The s3 strategy moves region; the second strategy changes type, so the
+	// directory's members must not carry over to it.
+	strategyType := s.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["backup_strategies"].(tftypes.List).ElementType.(tftypes.Object)
+	strategy := func(values map[string]string) tftypes.Value {
+		attributes := map[string]tftypes.Value{}
+		for name := range strategyType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(tftypes.String, nil)
+			if value, ok := values[name]; ok {
+				attributes[name] = tftypes.NewValue(tftypes.String, value)
+			}
+		}
+		return tftypes.NewValue(strategyType, attributes)
+	}
+	plan := tfsdk.Plan{Schema: s, Raw: resourceValue(ctx, s, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "controller-1"),
+		"backup_strategies": tftypes.NewValue(tftypes.List{ElementType: strategyType}, []tftypes.Value{
+			strategy(map[string]string{"type": "s3", "bucket": "dr-backups", "region": "us-west-2"}),
+			strategy(map[string]string{"type": "s3", "bucket": "offsite"}),
+		}),
+	})}
+
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: plan.Raw.Copy()}}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", resp.Diagnostics)
+	}
+
+	want := `[{"type":"s3","value":{"bucket":"dr-backups","region":"us-west-2","storage_class":"GLACIER"}},{"type":"s3","value":{"bucket":"offsite"}}]`
+	if got := string(posted["backup_strategies"]); got != want {
+		t.Errorf("posted backup_strategies\n got %s\nwant %s", got, want)
+	}
+}
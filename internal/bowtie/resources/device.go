@@ -0,0 +1,370 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &deviceResource{}
+var _ resource.ResourceWithImportState = &deviceResource{}
+var _ resource.ResourceWithConfigValidators = &deviceResource{}
+var _ resource.ResourceWithIdentity = &deviceResource{}
+
+// deviceIdentity lists the attributes that identify a device on import in
+// place of its ID.
+var deviceIdentity = []identityAttribute{
+	{name: "serial", description: "The device serial number."},
+}
+
+type deviceResource struct {
+	client *client.Client
+}
+
+type deviceResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Serial          types.String `tfsdk:"serial"`
+	State           types.String `tfsdk:"state"`
+	AssignedToUser  types.String `tfsdk:"assigned_to_user"`
+	OwnedByOrg      types.Bool   `tfsdk:"owned_by_org"`
+	DeleteOnDestroy types.Bool   `tfsdk:"delete_on_destroy"`
+	Name            types.String `tfsdk:"name"`
+	IPV6            types.String `tfsdk:"ipv6"`
+	PublicKey       types.String `tfsdk:"public_key"`
+	DeviceType      types.String `tfsdk:"device_type"`
+	DeviceOS        types.String `tfsdk:"device_os"`
+}
+
+func NewDeviceResource() resource.Resource {
+	return &deviceResource{}
+}
+
+func (d *deviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device"
+}
+
+func (d *deviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Manage the lifecycle of an enrolled device: whether it is approved, the user it is assigned to, and
+whether the organization owns it.
+
+**Note**: Devices enroll themselves when the Bowtie client first connects, so this resource **adopts**
+an existing device rather than creating one. Identify it by ` + "`id`" + ` or by ` + "`serial`" + `, for example from
+an asset inventory. Destroying the resource only stops managing the device unless
+` + "`delete_on_destroy`" + ` is set; to retire a device and keep its record, set ` + "`state = \"rejected\"`" + `.
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The device's unique identifier. Set this or `serial` to choose the device to adopt.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"serial": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The device serial number. Set this or `id` to choose the device to adopt; the serial must match exactly one device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Enrollment state: `pending`, `accepted` (approved), or `rejected`. Unset keeps the device's current state.",
+				Validators:          []validator.String{stringvalidator.OneOf("pending", "accepted", "rejected")},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"assigned_to_user": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the user the device is assigned to, or an empty string for an unassigned device. Set it to `\"\"` to remove the assignment. Unset keeps the device's current assignment.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"owned_by_org": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the device is owned by the organization rather than by its user. Unset keeps the device's current ownership.",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"delete_on_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Delete the device from Bowtie when the resource is destroyed. By default destroying the resource leaves the device in place.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The device name.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"ipv6": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The device's assigned IPv6 prefix.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"public_key": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The device's VPN public key.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"device_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The device type.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"device_os": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The device operating system.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (d *deviceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("serial"),
+		),
+	}
+}
+
+func (d *deviceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected *client.Client, got: %T, please report this to the provider.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *deviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan deviceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	device, err := d.adopt(ctx, plan.ID, plan.Serial)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to adopt device", apiErrorDetail(err))
+		return
+	}
+
+	d.apply(ctx, device, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (d *deviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state deviceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
+	device, err := d.client.GetDevice(ctx, state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("id"),
+				"Device not found, removing from state",
+				state.ID.ValueString(),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read device", apiErrorDetail(err))
+		return
+	}
+
+	mapDeviceToState(device, &state)
+	// Imported devices have no configuration yet; fall back to the default.
+	if state.DeleteOnDestroy.IsNull() {
+		state.DeleteOnDestroy = types.BoolValue(false)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *deviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan deviceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	device, err := d.client.GetDevice(ctx, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read device before update", apiErrorDetail(err))
+		return
+	}
+
+	d.apply(ctx, device, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (d *deviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state deviceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DeleteOnDestroy.ValueBool() {
+		return
+	}
+	if err := d.client.DeleteDevice(ctx, state.ID.ValueString()); err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Failed to delete device",
+			"Unexpected error deleting device "+state.ID.ValueString()+": "+apiErrorDetail(err),
+		)
+	}
+}
+
+func (d *deviceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	naturalIdentitySchema(resp, deviceIdentity...)
+}
+
+func (d *deviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importByIdentity(ctx, req, resp, deviceIdentity, func(ctx context.Context, values map[string]string) (string, error) {
+		return d.findBySerial(ctx, values["serial"])
+	})
+}
+
+// adopt finds the existing device a new resource manages, by its ID or, when
+// no ID is configured, by its serial number. When both are configured they
+// must name the same device.
+func (d *deviceResource) adopt(ctx context.Context, id, serial types.String) (client.Device, error) {
+	if !isSet(id) {
+		found, err := d.findBySerial(ctx, serial.ValueString())
+		if err != nil {
+			return client.Device{}, err
+		}
+		id = types.StringValue(found)
+	}
+
+	device, err := d.client.GetDevice(ctx, id.ValueString())
+	if err != nil {
+		return client.Device{}, err
+	}
+	if isSet(serial) && device.Serial != serial.ValueString() {
+		return client.Device{}, fmt.Errorf("device %s has the serial %q, not %q", device.ID, device.Serial, serial.ValueString())
+	}
+	return device, nil
+}
+
+func (d *deviceResource) findBySerial(ctx context.Context, serial string) (string, error) {
+	devices, err := d.client.ListDevices(ctx)
+	if err != nil {
+		return "", err
+	}
+	serials := map[string]string{}
+	for id, device := range devices {
+		serials[id] = device.Serial
+	}
+	return matchOne("device", "serial", serial, serials)
+}
+
+// apply writes the planned lifecycle settings onto device and reads the result
+// back into plan. Settings left unset keep the device's current value.
+func (d *deviceResource) apply(ctx context.Context, device client.Device, plan *deviceResourceModel, diags *diag.Diagnostics) {
+	state := device.State
+	if isSet(plan.State) {
+		state = plan.State.ValueString()
+	}
+
+	var assignedToUser *string
+	if device.AssignedToUser != "" {
+		assignedToUser = &device.AssignedToUser
+	}
+	if isSet(plan.AssignedToUser) {
+		assignedToUser = nil
+		if user := plan.AssignedToUser.ValueString(); user != "" {
+			assignedToUser = &user
+		}
+	}
+
+	var ownedByOrg *string
+	if device.OwnedByOrg != "" {
+		ownedByOrg = &device.OwnedByOrg
+	}
+	if isSetBool(plan.OwnedByOrg) {
+		ownedByOrg = nil
+		if plan.OwnedByOrg.ValueBool() {
+			org, err := d.client.GetOrganization(ctx)
+			if err != nil {
+				diags.AddError("Failed to read organization", apiErrorDetail(err))
+				return
+			}
+			ownedByOrg = &org.ID
+		}
+	}
+
+	if err := d.client.UpdateDeviceLifecycle(ctx, device.ID, state, assignedToUser, ownedByOrg); err != nil {
+		diags.AddError(
+			"Failed to update device",
+			"Unexpected error updating device "+device.ID+": "+apiErrorDetail(err),
+		)
+		return
+	}
+
+	updated, err := d.client.GetDevice(ctx, device.ID)
+	if err != nil {
+		diags.AddError("Failed to read device after update", apiErrorDetail(err))
+		return
+	}
+	mapDeviceToState(updated, plan)
+}
+
+// mapDeviceToState copies the server representation into the Terraform model.
+// The device reports an empty string for an unassigned user, which is kept so
+// that an assignment cleared with "" reads back as configured, and for an
+// unowned device.
+func mapDeviceToState(device client.Device, state *deviceResourceModel) {
+	state.ID = types.StringValue(device.ID)
+	state.Serial = types.StringValue(device.Serial)
+	state.State = types.StringValue(device.State)
+	state.AssignedToUser = types.StringValue(device.AssignedToUser)
+	state.OwnedByOrg = types.BoolValue(device.OwnedByOrg != "")
+	state.Name = types.StringValue(device.Name)
+	state.IPV6 = types.StringValue(device.IPV6)
+	state.PublicKey = types.StringValue(device.PublicKey)
+	state.DeviceType = types.StringValue(device.DeviceType)
+	state.DeviceOS = types.StringValue(device.DeviceOS)
+}
@@ -17,6 +17,13 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &deviceGroupResource{}
 var _ resource.ResourceWithImportState = &deviceGroupResource{}
+var _ resource.ResourceWithIdentity = &deviceGroupResource{}
+
+// deviceGroupIdentity lists the attributes that identify a device group on import
+// in place of its ID.
+var deviceGroupIdentity = []identityAttribute{
+	{name: "name", description: "The name of the device group."},
+}
 
 type deviceGroupResource struct {
 	client *client.Client
@@ -87,7 +94,7 @@ func (d *deviceGroupResource) Create(ctx context.Context, req resource.CreateReq
 		plan.ID = types.StringValue(uuid.NewString())
 	}
 
-	err := d.client.UpsertDeviceGroup(plan.ID.ValueString(), plan.Name.ValueString(), descriptionPointer(plan.Description))
+	err := d.client.UpsertDeviceGroup(ctx, plan.ID.ValueString(), plan.Name.ValueString(), descriptionPointer(plan.Description))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create device group",
@@ -97,6 +104,7 @@ func (d *deviceGroupResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (d *deviceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -105,12 +113,13 @@ func (d *deviceGroupResource) Read(ctx context.Context, req resource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	groups, err := d.client.GetDeviceGroups()
+	groups, err := d.client.GetDeviceGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to read device group",
-			"Unexpected error reading device group "+state.ID.ValueString()+": "+err.Error(),
+			"Unexpected error reading device group "+state.ID.ValueString()+": "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -143,7 +152,7 @@ func (d *deviceGroupResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	err := d.client.UpsertDeviceGroup(plan.ID.ValueString(), plan.Name.ValueString(), descriptionPointer(plan.Description))
+	err := d.client.UpsertDeviceGroup(ctx, plan.ID.ValueString(), plan.Name.ValueString(), descriptionPointer(plan.Description))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to update device group",
@@ -153,6 +162,7 @@ func (d *deviceGroupResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (d *deviceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -162,7 +172,7 @@ func (d *deviceGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	if err := d.client.DeleteDeviceGroup(state.ID.ValueString()); err != nil {
+	if err := d.client.DeleteDeviceGroup(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to delete device group",
 			"Unexpected error deleting device group "+state.ID.ValueString()+": "+err.Error(),
@@ -170,8 +180,22 @@ func (d *deviceGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 }
 
+func (d *deviceGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	naturalIdentitySchema(resp, deviceGroupIdentity...)
+}
+
 func (d *deviceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIdentity(ctx, req, resp, deviceGroupIdentity, func(ctx context.Context, values map[string]string) (string, error) {
+		groups, err := d.client.GetDeviceGroups(ctx)
+		if err != nil {
+			return "", err
+		}
+		names := map[string]string{}
+		for id, group := range groups {
+			names[id] = group.Name
+		}
+		return matchOne("device group", "name", values["name"], names)
+	})
 }
 
 // descriptionPointer converts an optional string attribute into the pointer the
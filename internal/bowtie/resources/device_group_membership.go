@@ -0,0 +1,372 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &deviceGroupMembershipResource{}
+var _ resource.ResourceWithImportState = &deviceGroupMembershipResource{}
+var _ resource.ResourceWithConfigValidators = &deviceGroupMembershipResource{}
+var _ resource.ResourceWithValidateConfig = &deviceGroupMembershipResource{}
+var _ resource.ResourceWithModifyPlan = &deviceGroupMembershipResource{}
+var _ resource.ResourceWithIdentity = &deviceGroupMembershipResource{}
+
+type deviceGroupMembershipResource struct {
+	client *client.Client
+}
+
+type deviceGroupMembershipResourceModel struct {
+	GroupID   types.String         `tfsdk:"group_id"`
+	Devices   types.Set            `tfsdk:"devices"`
+	Selector  *deviceSelectorModel `tfsdk:"selector"`
+	MemberIDs types.Set            `tfsdk:"member_ids"`
+}
+
+// deviceSelectorModel matches devices by their attributes. A device matches
+// when it satisfies every criterion that is set.
+type deviceSelectorModel struct {
+	DeviceOS     types.String `tfsdk:"device_os"`
+	DeviceType   types.String `tfsdk:"device_type"`
+	OwnedByOrg   types.Bool   `tfsdk:"owned_by_org"`
+	SerialPrefix types.String `tfsdk:"serial_prefix"`
+}
+
+func NewDeviceGroupMembershipResource() resource.Resource {
+	return &deviceGroupMembershipResource{}
+}
+
+func (d *deviceGroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_group_membership"
+}
+
+func (d *deviceGroupMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Used to set the membership of a device group. The group's members are the devices listed in
+` + "`devices`" + ` together with every device that matches ` + "`selector`" + `; any other device is removed from the
+group. Each device group can only be associated with a single membership resource.
+
+The selector is evaluated again on every plan, so devices that start matching it after they
+enroll are added on the next apply.
+`,
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the device group whose members are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"devices": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "IDs of devices that are members of the group.",
+			},
+			"selector": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Adds every device that matches all of the criteria set here.",
+				Attributes: map[string]schema.Attribute{
+					"device_os": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Match devices running this operating system, compared without regard to case.",
+					},
+					"device_type": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Match devices of this type, compared without regard to case.",
+					},
+					"owned_by_org": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Match devices the organization owns (`true`) or does not own (`false`).",
+					},
+					"serial_prefix": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Match devices whose serial number starts with this prefix.",
+					},
+				},
+			},
+			"member_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of every device in the group: the listed `devices` and those matching `selector`.",
+			},
+		},
+	}
+}
+
+func (d *deviceGroupMembershipResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("devices"),
+			path.MatchRoot("selector"),
+		),
+	}
+}
+
+func (d *deviceGroupMembershipResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config deviceGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Selector == nil {
+		return
+	}
+
+	s := config.Selector
+	if s.DeviceOS.IsNull() && s.DeviceType.IsNull() && s.OwnedByOrg.IsNull() && s.SerialPrefix.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("selector"),
+			"Empty device selector",
+			"Set at least one of device_os, device_type, owned_by_org, or serial_prefix. A selector without criteria would match every device.",
+		)
+	}
+}
+
+func (d *deviceGroupMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected *client.Client, got: %T, please report this to the provider.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *deviceGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan deviceGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.setMembership(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, "group_id", plan.GroupID)...)
+}
+
+func (d *deviceGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state deviceGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, "group_id", state.GroupID)...)
+
+	membership, err := d.client.ListDevicesInGroup(ctx, state.GroupID.ValueString())
+	if isNotFoundError(err) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("group_id"),
+			"device group not found, removing membership from state",
+			state.GroupID.ValueString(),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed listing devices in group",
+			"Unexpected error listing devices in device group: "+state.GroupID.ValueString()+" err: "+apiErrorDetail(err),
+		)
+		return
+	}
+
+	members, diags := types.SetValueFrom(ctx, types.StringType, membership.Devices)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.MemberIDs = members
+	// A freshly imported membership has no configuration yet; adopt the
+	// current members as its devices so the first plan keeps them.
+	if state.Devices.IsNull() && state.Selector == nil {
+		state.Devices = members
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (d *deviceGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan deviceGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.setMembership(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, "group_id", plan.GroupID)...)
+}
+
+func (d *deviceGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state deviceGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := d.client.SetDeviceGroupMembership(ctx, state.GroupID.ValueString(), []string{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to remove all devices from the device group",
+			"Unexpected error removing devices from device group: "+state.GroupID.ValueString()+" err: "+apiErrorDetail(err),
+		)
+	}
+}
+
+// ModifyPlan evaluates the selector against the organization's current
+// devices, so that devices which started or stopped matching it since the
+// last apply show up as a change to member_ids.
+func (d *deviceGroupMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || d.client == nil {
+		return
+	}
+
+	var plan deviceGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, known := d.desiredMembers(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || !known {
+		return
+	}
+	value, diags := types.SetValueFrom(ctx, types.StringType, members)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("member_ids"), value)...)
+}
+
+func (d *deviceGroupMembershipResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	singleIdentitySchema(resp, "group_id", "ID of the device group whose members are managed.")
+}
+
+func (d *deviceGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The membership is keyed by the device group it belongs to, so import the
+	// ID into group_id rather than a non-existent id attribute.
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("group_id"), path.Root("group_id"), req, resp)
+}
+
+// setMembership makes the planned members the group's only members and
+// records them in member_ids.
+func (d *deviceGroupMembershipResource) setMembership(ctx context.Context, plan *deviceGroupMembershipResourceModel, diags *diag.Diagnostics) {
+	var members []string
+	if plan.MemberIDs.IsUnknown() || plan.MemberIDs.IsNull() {
+		// Devices referenced before they were known are only resolved now.
+		var known bool
+		members, known = d.desiredMembers(ctx, *plan, diags)
+		if diags.HasError() {
+			return
+		}
+		if !known {
+			diags.AddError("Unknown device group members", "The devices of the membership are still unknown at apply time.")
+			return
+		}
+	} else {
+		diags.Append(plan.MemberIDs.ElementsAs(ctx, &members, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if err := d.client.SetDeviceGroupMembership(ctx, plan.GroupID.ValueString(), members); err != nil {
+		diags.AddError(
+			"Failed to set device group membership",
+			"Unexpected error setting device group membership: "+plan.GroupID.ValueString()+" err: "+apiErrorDetail(err),
+		)
+		return
+	}
+
+	value, setDiags := types.SetValueFrom(ctx, types.StringType, members)
+	diags.Append(setDiags...)
+	plan.MemberIDs = value
+}
+
+// desiredMembers returns the listed devices together with those matching the
+// selector. It reports false when the configuration is not yet known.
+func (d *deviceGroupMembershipResource) desiredMembers(ctx context.Context, plan deviceGroupMembershipResourceModel, diags *diag.Diagnostics) ([]string, bool) {
+	if plan.Devices.IsUnknown() || (plan.Selector != nil && !plan.Selector.known()) {
+		return nil, false
+	}
+
+	var listed []string
+	if !plan.Devices.IsNull() {
+		diags.Append(plan.Devices.ElementsAs(ctx, &listed, false)...)
+	}
+	var devices map[string]client.Device
+	if plan.Selector != nil {
+		var err error
+		devices, err = d.client.ListDevices(ctx)
+		if err != nil {
+			diags.AddError("Failed to list devices", apiErrorDetail(err))
+			return nil, false
+		}
+	}
+	return selectDeviceGroupMembers(listed, plan.Selector, devices), true
+}
+
+// selectDeviceGroupMembers returns the sorted union of listed and the IDs of
+// the devices matching selector.
+func selectDeviceGroupMembers(listed []string, selector *deviceSelectorModel, devices map[string]client.Device) []string {
+	members := map[string]bool{}
+	for _, id := range listed {
+		members[id] = true
+	}
+	if selector != nil {
+		for id, device := range devices {
+			if selector.matches(device) {
+				members[id] = true
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (s *deviceSelectorModel) known() bool {
+	return !s.DeviceOS.IsUnknown() && !s.DeviceType.IsUnknown() && !s.OwnedByOrg.IsUnknown() && !s.SerialPrefix.IsUnknown()
+}
+
+func (s *deviceSelectorModel) matches(device client.Device) bool {
+	if isSet(s.DeviceOS) && !strings.EqualFold(device.DeviceOS, s.DeviceOS.ValueString()) {
+		return false
+	}
+	if isSet(s.DeviceType) && !strings.EqualFold(device.DeviceType, s.DeviceType.ValueString()) {
+		return false
+	}
+	if isSetBool(s.OwnedByOrg) && (device.OwnedByOrg != "") != s.OwnedByOrg.ValueBool() {
+		return false
+	}
+	if isSet(s.SerialPrefix) && !strings.HasPrefix(device.Serial, s.SerialPrefix.ValueString()) {
+		return false
+	}
+	return true
+}
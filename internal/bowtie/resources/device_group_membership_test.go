@@ -0,0 +1,170 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSelectDeviceGroupMembers(t *testing.T) {
+	devices := map[string]client.Device{
+		"laptop-1": {ID: "laptop-1", Serial: "C02XK0ABJG5J", DeviceOS: "macOS", DeviceType: "laptop", OwnedByOrg: "org-1"},
+		"laptop-2": {ID: "laptop-2", Serial: "C02YL1BCKH6K", DeviceOS: "macOS", DeviceType: "laptop"},
+		"phone-1":  {ID: "phone-1", Serial: "F17ZM2CDLJ7L", DeviceOS: "iOS", DeviceType: "phone", OwnedByOrg: "org-1"},
+	}
+	selector := func(os, serialPrefix string, ownedByOrg *bool) *deviceSelectorModel {
+		s := &deviceSelectorModel{
+			DeviceOS:     types.StringNull(),
+			DeviceType:   types.StringNull(),
+			OwnedByOrg:   types.BoolPointerValue(ownedByOrg),
+			SerialPrefix: types.StringNull(),
+		}
+		if os != "" {
+			s.DeviceOS = types.StringValue(os)
+		}
+		if serialPrefix != "" {
+			s.SerialPrefix = types.StringValue(serialPrefix)
+		}
+		return s
+	}
+	owned := true
+
+	for name, tc := range map[string]struct {
+		listed   []string
+		selector *deviceSelectorModel
+		want     []string
+	}{
+		"listed only":                {listed: []string{"phone-1"}, want: []string{"phone-1"}},
+		"operating system any case":  {selector: selector("macos", "", nil), want: []string{"laptop-1", "laptop-2"}},
+		"every criterion must match": {selector: selector("macos", "", &owned), want: []string{"laptop-1"}},
+		"serial prefix":              {selector: selector("", "C02", nil), want: []string{"laptop-1", "laptop-2"}},
+		"listed and selected":        {listed: []string{"phone-1", "laptop-1"}, selector: selector("", "C02Y", nil), want: []string{"laptop-1", "laptop-2", "phone-1"}},
+	} {
+		got := selectDeviceGroupMembers(tc.listed, tc.selector, devices)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: members %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+// membershipValue is a bowtie_device_group_membership of group-1 with the
+// given attributes set: selector by operating system, and devices and
+// member_ids as sets of IDs.
+func membershipValue(ctx context.Context, s schema.Schema, selectOS string, devices, memberIDs []string) tftypes.Value {
+	stringSet := func(ids []string) tftypes.Value {
+		if ids == nil {
+			return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil)
+		}
+		elements := []tftypes.Value{}
+		for _, id := range ids {
+			elements = append(elements, tftypes.NewValue(tftypes.String, id))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+	}
+	values := map[string]tftypes.Value{
+		"group_id":   tftypes.NewValue(tftypes.String, "group-1"),
+		"devices":    stringSet(devices),
+		"member_ids": stringSet(memberIDs),
+	}
+	if selectOS != "" {
+		selectorType := s.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["selector"].(tftypes.Object)
+		selector := map[string]tftypes.Value{}
+		for name, attributeType := range selectorType.AttributeTypes {
+			selector[name] = tftypes.NewValue(attributeType, nil)
+		}
+		selector["device_os"] = tftypes.NewValue(tftypes.String, selectOS)
+		values["selector"] = tftypes.NewValue(selectorType, selector)
+	}
+	return resourceValue(ctx, s, values)
+}
+
+func membershipSchema(ctx context.Context, d *deviceGroupMembershipResource) schema.Schema {
+	resp := resource.SchemaResponse{}
+	d.Schema(ctx, resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+func TestDeviceGroupMembershipAddsNewlyMatchingDevices(t *testing.T) {
+	ctx := context.Background()
+	f, c := newFakeDeviceController(t,
+		client.Device{ID: "laptop-1", DeviceOS: "macOS"},
+		client.Device{ID: "phone-1", DeviceOS: "iOS"},
+		// Enrolled since the last apply.
+		client.Device{ID: "laptop-2", DeviceOS: "macOS"},
+	)
+	f.groups["group-1"] = []string{"laptop-1"}
+	d := &deviceGroupMembershipResource{client: c}
+	s := membershipSchema(ctx, d)
+
+	// Nothing in the configuration changed, so the plan carries member_ids
+	// over from state.
+	state := tfsdk.State{Schema: s, Raw: membershipValue(ctx, s, "macos", nil, []string{"laptop-1"})}
+	plan := tfsdk.Plan{Schema: s, Raw: state.Raw.Copy()}
+	planResp := resource.ModifyPlanResponse{Plan: plan}
+	d.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &planResp)
+	if planResp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan: %v", planResp.Diagnostics)
+	}
+	var planned []string
+	planResp.Diagnostics.Append(planResp.Plan.GetAttribute(ctx, path.Root("member_ids"), &planned)...)
+	if want := []string{"laptop-1", "laptop-2"}; !reflect.DeepEqual(planned, want) {
+		t.Fatalf("planned member_ids %v, want %v", planned, want)
+	}
+
+	updateResp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: planResp.Plan.Raw.Copy()}}
+	d.Update(ctx, resource.UpdateRequest{Plan: planResp.Plan, State: state}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	if want := []string{"laptop-1", "laptop-2"}; !reflect.DeepEqual(f.groups["group-1"], want) {
+		t.Errorf("group members %v, want %v", f.groups["group-1"], want)
+	}
+}
+
+func TestDeviceGroupMembershipReadAdoptsMembersOnImport(t *testing.T) {
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		selectOS    string
+		wantDevices []string
+	}{
+		// An import sets only group_id.
+		"imported": {wantDevices: []string{"laptop-1", "phone-1"}},
+		// Members matched by a configured selector are not listed as devices.
+		"selected": {selectOS: "macos"},
+	} {
+		f, c := newFakeDeviceController(t)
+		f.groups["group-1"] = []string{"laptop-1", "phone-1"}
+		d := &deviceGroupMembershipResource{client: c}
+		s := membershipSchema(ctx, d)
+
+		state := tfsdk.State{Schema: s, Raw: membershipValue(ctx, s, tc.selectOS, nil, nil)}
+		resp := resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state.Raw.Copy()}}
+		d.Read(ctx, resource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%s: Read: %v", name, resp.Diagnostics)
+			continue
+		}
+
+		var devices, members []string
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("devices"), &devices)...)
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("member_ids"), &members)...)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%s: reading state: %v", name, resp.Diagnostics)
+			continue
+		}
+		if !reflect.DeepEqual(devices, tc.wantDevices) {
+			t.Errorf("%s: devices %v, want %v", name, devices, tc.wantDevices)
+		}
+		if want := []string{"laptop-1", "phone-1"}; !reflect.DeepEqual(members, want) {
+			t.Errorf("%s: member_ids %v, want %v", name, members, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeDeviceController serves the device and device group endpoints of a
+// Controller from devices and groups, applying updates to them and recording
+// the last lifecycle update.
+type fakeDeviceController struct {
+	t       *testing.T
+	devices map[string]client.Device
+	groups  map[string][]string
+	upsert  map[string]*string
+}
+
+func newFakeDeviceController(t *testing.T, devices ...client.Device) (*fakeDeviceController, *client.Client) {
+	t.Helper()
+	f := &fakeDeviceController{t: t, devices: map[string]client.Device{}, groups: map[string][]string{}}
+	for _, device := range devices {
+		f.devices[device.ID] = device
+	}
+	ts := httptest.NewServer(f)
+	t.Cleanup(ts.Close)
+	return f, newTestClient(t, ts)
+}
+
+func (f *fakeDeviceController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch path := strings.TrimPrefix(r.URL.Path, "/-net/api/v0"); {
+	case r.Method == http.MethodGet && path == "/device":
+		_ = json.NewEncoder(w).Encode(client.DevicePayload{Devices: f.devices})
+	case r.Method == http.MethodGet && path == "/organization":
+		_ = json.NewEncoder(w).Encode(client.Organization{ID: "org-1"})
+	case r.Method == http.MethodPost && path == "/device/upsert":
+		body, _ := io.ReadAll(r.Body)
+		var upsert struct {
+			ID             string  `json:"id"`
+			State          string  `json:"state"`
+			AssignedToUser *string `json:"assigned_to_user"`
+			OwnedByOrg     *string `json:"owned_by_org"`
+		}
+		if err := json.Unmarshal(body, &upsert); err != nil {
+			f.t.Errorf("decoding device upsert: %v", err)
+		}
+		f.upsert = map[string]*string{"state": &upsert.State, "assigned_to_user": upsert.AssignedToUser, "owned_by_org": upsert.OwnedByOrg}
+
+		device := f.devices[upsert.ID]
+		device.State = upsert.State
+		device.AssignedToUser, device.OwnedByOrg = "", ""
+		if upsert.AssignedToUser != nil {
+			device.AssignedToUser = *upsert.AssignedToUser
+		}
+		if upsert.OwnedByOrg != nil {
+			device.OwnedByOrg = *upsert.OwnedByOrg
+		}
+		f.devices[upsert.ID] = device
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/device_group/") && strings.HasSuffix(path, "/list"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/device_group/"), "/list")
+		members, ok := f.groups[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(client.DeviceGroupMembership{ID: id, Devices: members})
+	case r.Method == http.MethodPost && strings.HasPrefix(path, "/device_group/") && strings.HasSuffix(path, "/set_membership"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/device_group/"), "/set_membership")
+		var membership struct {
+			Devices []struct {
+				ID string `json:"id"`
+			} `json:"devices"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &membership); err != nil {
+			f.t.Errorf("decoding device group membership: %v", err)
+		}
+		f.groups[id] = []string{}
+		for _, device := range membership.Devices {
+			f.groups[id] = append(f.groups[id], device.ID)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestDeviceAdopt(t *testing.T) {
+	_, c := newFakeDeviceController(t,
+		client.Device{ID: "laptop-1", Serial: "C02XK0ABJG5J"},
+		client.Device{ID: "laptop-2", Serial: "C02YL1BCKH6K"},
+		// A device re-enrolled after a reinstall keeps its serial.
+		client.Device{ID: "phone-1", Serial: "F17ZM2CDLJ7L"},
+		client.Device{ID: "phone-2", Serial: "F17ZM2CDLJ7L"},
+	)
+	d := &deviceResource{client: c}
+
+	for name, tc := range map[string]struct {
+		id, serial types.String
+		want       string
+		wantErr    string
+	}{
+		"by id":                 {id: types.StringValue("laptop-1"), serial: types.StringNull(), want: "laptop-1"},
+		"by serial":             {id: types.StringNull(), serial: types.StringValue("C02YL1BCKH6K"), want: "laptop-2"},
+		"id and serial agree":   {id: types.StringValue("laptop-1"), serial: types.StringValue("C02XK0ABJG5J"), want: "laptop-1"},
+		"id and serial differ":  {id: types.StringValue("laptop-1"), serial: types.StringValue("C02YL1BCKH6K"), wantErr: `device laptop-1 has the serial "C02XK0ABJG5J", not "C02YL1BCKH6K"`},
+		"unknown serial":        {id: types.StringNull(), serial: types.StringValue("XYZ"), wantErr: `no device has the serial "XYZ"`},
+		"serial of two devices": {id: types.StringNull(), serial: types.StringValue("F17ZM2CDLJ7L"), wantErr: "2 devices have the serial \"F17ZM2CDLJ7L\" (phone-1, phone-2)"},
+	} {
+		device, err := d.adopt(context.Background(), tc.id, tc.serial)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: error %v, want %q", name, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		} else if device.ID != tc.want {
+			t.Errorf("%s: adopted %s, want %s", name, device.ID, tc.want)
+		}
+	}
+}
+
+func TestDeviceApply(t *testing.T) {
+	assigned := client.Device{ID: "laptop-1", State: "accepted", AssignedToUser: "user-1", OwnedByOrg: "org-1"}
+	unassigned := client.Device{ID: "laptop-1", State: "pending"}
+
+	for name, tc := range map[string]struct {
+		device         client.Device
+		assignedToUser types.String
+		ownedByOrg     types.Bool
+		wantUser       string
+		wantOrg        string
+	}{
+		"unset keeps the assignment and ownership": {device: assigned, assignedToUser: types.StringNull(), ownedByOrg: types.BoolNull(), wantUser: "user-1", wantOrg: "org-1"},
+		"unset keeps an unclaimed device":          {device: unassigned, assignedToUser: types.StringNull(), ownedByOrg: types.BoolNull()},
+		"owned by the organization":                {device: unassigned, assignedToUser: types.StringNull(), ownedByOrg: types.BoolValue(true), wantOrg: "org-1"},
+		"not owned clears":                         {device: assigned, assignedToUser: types.StringNull(), ownedByOrg: types.BoolValue(false), wantUser: "user-1"},
+		"assigned to a user":                       {device: unassigned, assignedToUser: types.StringValue("user-2"), ownedByOrg: types.BoolNull(), wantUser: "user-2"},
+		"empty assignment clears":                  {device: assigned, assignedToUser: types.StringValue(""), ownedByOrg: types.BoolNull(), wantOrg: "org-1"},
+	} {
+		f, c := newFakeDeviceController(t, tc.device)
+		d := &deviceResource{client: c}
+
+		plan := deviceResourceModel{State: types.StringNull(), AssignedToUser: tc.assignedToUser, OwnedByOrg: tc.ownedByOrg}
+		var diags diag.Diagnostics
+		d.apply(context.Background(), tc.device, &plan, &diags)
+		if diags.HasError() {
+			t.Errorf("%s: %v", name, diags)
+			continue
+		}
+
+		// The Controller clears an assignment or ownership posted as null.
+		for field, want := range map[string]string{"assigned_to_user": tc.wantUser, "owned_by_org": tc.wantOrg} {
+			got := f.upsert[field]
+			if want == "" && got != nil || want != "" && (got == nil || *got != want) {
+				t.Errorf("%s: posted %s %v, want %q", name, field, got, want)
+			}
+		}
+		if *f.upsert["state"] != tc.device.State {
+			t.Errorf("%s: posted state %q, want the current %q", name, *f.upsert["state"], tc.device.State)
+		}
+		if plan.AssignedToUser.ValueString() != tc.wantUser || plan.OwnedByOrg.ValueBool() != (tc.wantOrg != "") {
+			t.Errorf("%s: read back assigned_to_user %s and owned_by_org %s", name, plan.AssignedToUser, plan.OwnedByOrg)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &dnsResource{}
 var _ resource.ResourceWithImportState = &dnsResource{}
+var _ resource.ResourceWithIdentity = &dnsResource{}
 
 type dnsResource struct {
 	client *client.Client
@@ -231,6 +232,7 @@ func (d *dnsResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	err := d.client.UpsertDNS(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		servers,
@@ -279,6 +281,7 @@ func (d *dnsResource) Create(ctx context.Context, req resource.CreateRequest, re
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (d *dnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -287,12 +290,13 @@ func (d *dnsResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	dnss, err := d.client.GetDNS()
+	dnss, err := d.client.GetDNS(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed communicating with the bowtie api",
-			"Unexpected error reading DNS settings: "+err.Error(),
+			"Unexpected error reading DNS settings: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -390,7 +394,7 @@ func (d *dnsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		})
 	}
 
-	err := d.client.UpsertDNS(plan.ID.ValueString(), plan.Name.ValueString(), servers, includes, plan.IsDNS64.ValueBool(), plan.IsCounted.ValueBool(), plan.IsLog.ValueBool(), plan.IsDropA.ValueBool(), plan.IsDropAll.ValueBool(), plan.IsSearchDomain.ValueBool(), excludes)
+	err := d.client.UpsertDNS(ctx, plan.ID.ValueString(), plan.Name.ValueString(), servers, includes, plan.IsDNS64.ValueBool(), plan.IsCounted.ValueBool(), plan.IsLog.ValueBool(), plan.IsDropA.ValueBool(), plan.IsDropAll.ValueBool(), plan.IsSearchDomain.ValueBool(), excludes)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed updating the dns settings",
@@ -419,6 +423,7 @@ func (d *dnsResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (d *dnsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -428,7 +433,7 @@ func (d *dnsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	err := d.client.DeleteDNS(plan.ID.ValueString())
+	err := d.client.DeleteDNS(ctx, plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to delete the dns settings",
@@ -437,8 +442,12 @@ func (d *dnsResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 }
 
+func (d *dnsResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema(resp)
+}
+
 func (d *dnsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func mergeServerDetails(serverList []types.String, serverDetails []dnsServersResourceModel) []client.Server {
@@ -8,9 +8,11 @@ import (
 
 	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -20,17 +22,20 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &dnsBlockListResource{}
 var _ resource.ResourceWithImportState = &dnsBlockListResource{}
+var _ resource.ResourceWithIdentity = &dnsBlockListResource{}
 
 type dnsBlockListResource struct {
 	client *client.Client
 }
 
 type dnsBlockListResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	LastUpdated     types.String `tfsdk:"last_updated"`
-	Upstream        types.String `tfsdk:"upstream"`
-	OverrideToAllow types.List   `tfsdk:"override_to_allow"`
+	ID              types.String   `tfsdk:"id"`
+	Name            types.String   `tfsdk:"name"`
+	LastUpdated     types.String   `tfsdk:"last_updated"`
+	Upstream        types.String   `tfsdk:"upstream"`
+	OverrideToAllow types.List     `tfsdk:"override_to_allow"`
+	IsAllowlist     types.Bool     `tfsdk:"is_allowlist"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewDNSBlockListResource() resource.Resource {
@@ -68,6 +73,7 @@ func (bl *dnsBlockListResource) Schema(ctx context.Context, req resource.SchemaR
 Manage lists of DNS names that Controllers will reference to perform DNS-level blocking.
 
 Names may be given as upstream URLs which will be retrieved periodically.
+A list can instead be an allowlist, for example to resolve only the names a kiosk needs and deny the rest.
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -97,6 +103,20 @@ Names may be given as upstream URLs which will be retrieved periodically.
 				Optional:            true,
 				MarkdownDescription: "Optional list of DNS names to exclude from any retrieved DNS block lists.",
 			},
+			"is_allowlist": schema.BoolAttribute{
+				Default:             booldefault.StaticBool(false),
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the list names the only DNS names to allow rather than the names to block.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -115,10 +135,6 @@ func (bl *dnsBlockListResource) Configure(ctx context.Context, req resource.Conf
 	}
 
 	bl.client = client
-	// When creating block lists, they are fetched to confirm validity,
-	// and so we increase the timeout to ensure that the server has time
-	// to perform any requisite GETs for our blocklist URL.
-	bl.client.HTTPClient.Timeout = 30 * time.Second
 }
 
 func (bl *dnsBlockListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -128,6 +144,12 @@ func (bl *dnsBlockListResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	overrides := []string{}
 	resp.Diagnostics.Append(plan.OverrideToAllow.ElementsAs(ctx, &overrides, false)...)
 	if resp.Diagnostics.HasError() {
@@ -139,10 +161,12 @@ func (bl *dnsBlockListResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	err := bl.client.UpsertDNSBlockList(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		plan.Upstream.ValueString(),
 		strings.Join(overrides, "\n"),
+		plan.IsAllowlist.ValueBool(),
 	)
 
 	if err != nil {
@@ -156,6 +180,7 @@ func (bl *dnsBlockListResource) Create(ctx context.Context, req resource.CreateR
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (bl *dnsBlockListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -164,14 +189,21 @@ func (bl *dnsBlockListResource) Read(ctx context.Context, req resource.ReadReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Pull in the list of blocklists from the API; failures here
 	// indicate API-level errors like 400s or 500s
-	blocklists, err := bl.client.GetDNSBlockLists()
+	blocklists, err := bl.client.GetDNSBlockLists(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed retrieving DNS block list",
-			"Unexpected error retrieving DNS block list from Bowtie API: "+err.Error(),
+			"Unexpected error retrieving DNS block list from Bowtie API: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -198,6 +230,7 @@ func (bl *dnsBlockListResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 	state.OverrideToAllow = overrides
+	state.IsAllowlist = types.BoolValue(blocklist.IsAllowlist)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -210,6 +243,12 @@ func (bl *dnsBlockListResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	overrides := []string{}
 	resp.Diagnostics.Append(plan.OverrideToAllow.ElementsAs(ctx, &overrides, false)...)
 	if resp.Diagnostics.HasError() {
@@ -217,10 +256,12 @@ func (bl *dnsBlockListResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	err := bl.client.UpsertDNSBlockList(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		plan.Upstream.ValueString(),
 		strings.Join(overrides, "\n"),
+		plan.IsAllowlist.ValueBool(),
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -233,6 +274,7 @@ func (bl *dnsBlockListResource) Update(ctx context.Context, req resource.UpdateR
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (bl *dnsBlockListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -243,7 +285,13 @@ func (bl *dnsBlockListResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	err := bl.client.DeleteDNSBlockList(state.ID.ValueString())
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := bl.client.DeleteDNSBlockList(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed deleting DNS block list",
@@ -252,6 +300,10 @@ func (bl *dnsBlockListResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 }
 
+func (bl *dnsBlockListResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema(resp)
+}
+
 func (bl *dnsBlockListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
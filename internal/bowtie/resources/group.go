@@ -18,6 +18,13 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &groupResource{}
 var _ resource.ResourceWithImportState = &groupResource{}
+var _ resource.ResourceWithIdentity = &groupResource{}
+
+// groupIdentity lists the attributes that identify a group on import
+// in place of its ID.
+var groupIdentity = []identityAttribute{
+	{name: "name", description: "The human-readable name of the group."},
+}
 
 type groupResource struct {
 	client *client.Client
@@ -88,7 +95,7 @@ func (g *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		plan.ID = types.StringValue(uuid.NewString())
 	}
 
-	_, err := g.client.UpsertGroup(plan.ID.ValueString(), plan.Name.ValueString())
+	_, err := g.client.UpsertGroup(ctx, plan.ID.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating group",
@@ -99,6 +106,7 @@ func (g *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -110,8 +118,9 @@ func (g *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	groups, err := g.client.GetGroups()
+	groups, err := g.client.GetGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving the group",
@@ -143,7 +152,7 @@ func (g *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	id, err := g.client.UpsertGroup(plan.ID.ValueString(), plan.Name.ValueString())
+	id, err := g.client.UpsertGroup(ctx, plan.ID.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating group",
@@ -156,6 +165,7 @@ func (g *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (g *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -165,7 +175,7 @@ func (g *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := g.client.DeleteGroup(state.ID.ValueString())
+	err := g.client.DeleteGroup(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to delete the group",
@@ -174,6 +184,20 @@ func (g *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+func (g *groupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	naturalIdentitySchema(resp, groupIdentity...)
+}
+
 func (g *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIdentity(ctx, req, resp, groupIdentity, func(ctx context.Context, values map[string]string) (string, error) {
+		groups, err := g.client.GetGroups(ctx)
+		if err != nil {
+			return "", err
+		}
+		names := map[string]string{}
+		for id, group := range groups {
+			names[id] = group.Name
+		}
+		return matchOne("group", "name", values["name"], names)
+	})
 }
@@ -15,6 +15,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GroupMembershipResource{}
 var _ resource.ResourceWithImportState = &GroupMembershipResource{}
+var _ resource.ResourceWithIdentity = &GroupMembershipResource{}
 
 type GroupMembershipResource struct {
 	client *client.Client
@@ -82,7 +83,7 @@ func (g *GroupMembershipResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	err := g.client.SetGroupMembership(plan.GroupID.ValueString(), users)
+	err := g.client.SetGroupMembership(ctx, plan.GroupID.ValueString(), users)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to set group membership",
@@ -92,6 +93,7 @@ func (g *GroupMembershipResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, "group_id", plan.GroupID)...)
 }
 
 func (g *GroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -100,12 +102,22 @@ func (g *GroupMembershipResource) Read(ctx context.Context, req resource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	groupInfo, err := g.client.ListUsersInGroup(plan.GroupID.ValueString())
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, "group_id", plan.GroupID)...)
+
+	groupInfo, err := g.client.ListUsersInGroup(ctx, plan.GroupID.ValueString())
+	if isNotFoundError(err) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("group_id"),
+			"group not found, removing membership from state",
+			plan.GroupID.ValueString(),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed listing users in group",
-			"Unexpected error listing users in group: "+plan.GroupID.ValueString()+" err: "+err.Error(),
+			"Unexpected error listing users in group: "+plan.GroupID.ValueString()+" err: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -134,7 +146,7 @@ func (g *GroupMembershipResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	err := g.client.SetGroupMembership(plan.GroupID.ValueString(), users)
+	err := g.client.SetGroupMembership(ctx, plan.GroupID.ValueString(), users)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to set group membership",
@@ -151,7 +163,7 @@ func (g *GroupMembershipResource) Update(ctx context.Context, req resource.Updat
 
 	plan.Users = stateUsers
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
-
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, "group_id", plan.GroupID)...)
 }
 
 func (g *GroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -161,7 +173,7 @@ func (g *GroupMembershipResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	err := g.client.SetGroupMembership(plan.GroupID.ValueString(), []string{})
+	err := g.client.SetGroupMembership(ctx, plan.GroupID.ValueString(), []string{})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"failed to remove all users from the group",
@@ -170,8 +182,12 @@ func (g *GroupMembershipResource) Delete(ctx context.Context, req resource.Delet
 	}
 }
 
+func (g *GroupMembershipResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	singleIdentitySchema(resp, "group_id", "ID of the group whose members are managed.")
+}
+
 func (g *GroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// The membership is keyed by the group it belongs to, so import the ID into
 	// group_id rather than a non-existent id attribute.
-	resource.ImportStatePassthroughID(ctx, path.Root("group_id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("group_id"), path.Root("group_id"), req, resp)
 }
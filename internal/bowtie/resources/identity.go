@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// idIdentitySchema is the identity of objects the Controller addresses by
+// their ID alone, which is most of them.
+func idIdentitySchema(resp *resource.IdentitySchemaResponse) {
+	singleIdentitySchema(resp, "id", "Internal resource ID.")
+}
+
+// identityAttribute is a natural attribute, such as a name, that identifies an
+// object in place of its ID on import.
+type identityAttribute struct {
+	name        string
+	description string
+}
+
+// naturalIdentitySchema is the identity of objects that can be imported by
+// their ID or by all of the given natural attributes.
+func naturalIdentitySchema(resp *resource.IdentitySchemaResponse, natural ...identityAttribute) {
+	attributes := map[string]identityschema.Attribute{
+		"id": identityschema.StringAttribute{
+			OptionalForImport: true,
+			Description:       "Internal resource ID. Either this or " + joinAttributes(natural) + " is required for import.",
+		},
+	}
+	for _, attribute := range natural {
+		attributes[attribute.name] = identityschema.StringAttribute{
+			OptionalForImport: true,
+			Description:       attribute.description,
+		}
+	}
+	resp.IdentitySchema = identityschema.Schema{Attributes: attributes}
+}
+
+func joinAttributes(attributes []identityAttribute) string {
+	names := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		names = append(names, "`"+attribute.name+"`")
+	}
+	return strings.Join(names, " and ")
+}
+
+// importByIdentity imports the object named by an import ID, or by an import
+// identity holding either its ID or all of its natural attributes, which
+// resolve looks up. Once resolved the natural attributes are cleared from the
+// identity: only the ID is stable, and renaming the object must not change
+// its identity.
+func importByIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, natural []identityAttribute, resolve func(ctx context.Context, values map[string]string) (string, error)) {
+	if req.ID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+		return
+	}
+	if resp.Identity == nil {
+		return
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(resp.Identity.GetAttribute(ctx, path.Root("id"), &id)...)
+	values := map[string]string{}
+	for _, attribute := range natural {
+		var value types.String
+		resp.Diagnostics.Append(resp.Identity.GetAttribute(ctx, path.Root(attribute.name), &value)...)
+		if value.ValueString() != "" {
+			values[attribute.name] = value.ValueString()
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if id.ValueString() == "" {
+		if len(values) != len(natural) {
+			resp.Diagnostics.AddError(
+				"Incomplete Import Identity",
+				"Identify the object to import by `id`, or by "+joinAttributes(natural)+".",
+			)
+			return
+		}
+
+		resolved, err := resolve(ctx, values)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to resolve the import identity",
+				err.Error(),
+			)
+			return
+		}
+		id = types.StringValue(resolved)
+	}
+
+	for _, attribute := range natural {
+		resp.Diagnostics.Append(resp.Identity.SetAttribute(ctx, path.Root(attribute.name), types.StringNull())...)
+	}
+	resp.Diagnostics.Append(resp.Identity.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// matchOne returns the ID of the single candidate whose value is wanted.
+// candidates maps IDs to the value of the natural attribute; kind and
+// attribute only word the errors.
+func matchOne(kind, attribute, wanted string, candidates map[string]string) (string, error) {
+	var ids []string
+	for id, value := range candidates {
+		if value == wanted {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s has the %s %q", kind, attribute, wanted)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d %ss have the %s %q (%s); import by id instead", len(ids), kind, attribute, wanted, strings.Join(ids, ", "))
+	}
+}
+
+func singleIdentitySchema(resp *resource.IdentitySchemaResponse, attribute, description string) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			attribute: identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       description,
+			},
+		},
+	}
+}
+
+// setIdentity records value as the identity attribute of the same name. Read
+// sets it from prior state before looking the object up, so that an object
+// deleted out-of-band is still removed from state cleanly when its stored
+// state predates identities.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, attribute string, value types.String) diag.Diagnostics {
+	if identity == nil || value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return identity.SetAttribute(ctx, path.Root(attribute), value)
+}
+
+func setIDIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String) diag.Diagnostics {
+	return setIdentity(ctx, identity, "id", id)
+}
@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// groupImport runs an import of bowtie_group with the given import ID or
+// identity values, resolving names from groups.
+func groupImport(t *testing.T, id string, identity map[string]*string, groups map[string]string) resource.ImportStateResponse {
+	t.Helper()
+	ctx := context.Background()
+	r := &groupResource{}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	identityResp := resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+	if diags := identityResp.IdentitySchema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("identity schema is invalid: %v", diags)
+	}
+
+	identityType := identityResp.IdentitySchema.Type().TerraformType(ctx)
+	identityValue := tftypes.NewValue(identityType, nil)
+	if identity != nil {
+		values := map[string]tftypes.Value{}
+		for attribute := range identityResp.IdentitySchema.Attributes {
+			values[attribute] = tftypes.NewValue(tftypes.String, identity[attribute])
+		}
+		identityValue = tftypes.NewValue(identityType, values)
+	}
+
+	resp := resource.ImportStateResponse{
+		State:    tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		Identity: &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema, Raw: identityValue},
+	}
+	importByIdentity(ctx, resource.ImportStateRequest{ID: id}, &resp, groupIdentity, func(_ context.Context, values map[string]string) (string, error) {
+		return matchOne("group", "name", values["name"], groups)
+	})
+	return resp
+}
+
+func stateID(t *testing.T, resp resource.ImportStateResponse) string {
+	t.Helper()
+	var id types.String
+	if diags := resp.State.GetAttribute(context.Background(), path.Root("id"), &id); diags.HasError() {
+		t.Fatalf("reading id: %v", diags)
+	}
+	return id.ValueString()
+}
+
+func ptr(s string) *string { return &s }
+
+func TestImportByIdentity(t *testing.T) {
+	groups := map[string]string{
+		"1": "Administrators",
+		"2": "Engineering",
+		"3": "Engineering",
+	}
+
+	resp := groupImport(t, "1", nil, groups)
+	if resp.Diagnostics.HasError() || stateID(t, resp) != "1" {
+		t.Fatalf("import by ID: id %q, diagnostics %v", stateID(t, resp), resp.Diagnostics)
+	}
+
+	resp = groupImport(t, "", map[string]*string{"id": ptr("2")}, groups)
+	if resp.Diagnostics.HasError() || stateID(t, resp) != "2" {
+		t.Fatalf("import by identity id: id %q, diagnostics %v", stateID(t, resp), resp.Diagnostics)
+	}
+
+	resp = groupImport(t, "", map[string]*string{"name": ptr("Administrators")}, groups)
+	if resp.Diagnostics.HasError() || stateID(t, resp) != "1" {
+		t.Fatalf("import by name: id %q, diagnostics %v", stateID(t, resp), resp.Diagnostics)
+	}
+	var name types.String
+	resp.Identity.GetAttribute(context.Background(), path.Root("name"), &name)
+	if !name.IsNull() {
+		t.Errorf("name should be cleared from the stored identity, got %s", name)
+	}
+}
+
+func TestImportByIdentityErrors(t *testing.T) {
+	groups := map[string]string{
+		"2": "Engineering",
+		"3": "Engineering",
+	}
+
+	for name, tc := range map[string]struct {
+		identity map[string]*string
+		want     string
+	}{
+		"empty":     {map[string]*string{}, "Identify the object"},
+		"not found": {map[string]*string{"name": ptr("Finance")}, `no group has the name "Finance"`},
+		"ambiguous": {map[string]*string{"name": ptr("Engineering")}, "2 groups have the name"},
+	} {
+		resp := groupImport(t, "", tc.identity, groups)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("%s: expected an error", name)
+			continue
+		}
+		if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tc.want) {
+			t.Errorf("%s: error %q does not mention %q", name, detail, tc.want)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 var _ resource.Resource = &ipv4RangeResource{}
 var _ resource.ResourceWithImportState = &ipv4RangeResource{}
+var _ resource.ResourceWithIdentity = &ipv4RangeResource{}
 
 type ipv4RangeResource struct {
 	client *client.Client
@@ -102,7 +103,7 @@ func (r *ipv4RangeResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	out, err := r.client.UpsertIPv4Range(&client.OrgIPv4Range{
+	out, err := r.client.UpsertIPv4Range(ctx, &client.OrgIPv4Range{
 		Range:                   plan.Range.ValueString(),
 		AssignAddressesFromHere: plan.AssignAddressesFromHere.ValueString(),
 		SkipFirstNAddresses:     plan.SkipFirstNAddresses.ValueInt64(),
@@ -116,6 +117,7 @@ func (r *ipv4RangeResource) Create(ctx context.Context, req resource.CreateReque
 	plan.SkipFirstNAddresses = types.Int64Value(out.SkipFirstNAddresses)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *ipv4RangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -124,8 +126,9 @@ func (r *ipv4RangeResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	out, err := r.client.GetIPv4Range(state.ID.ValueString())
+	out, err := r.client.GetIPv4Range(ctx, state.ID.ValueString())
 	if err != nil {
 		if isNotFoundError(err) {
 			resp.Diagnostics.AddAttributeWarning(
@@ -136,7 +139,7 @@ func (r *ipv4RangeResource) Read(ctx context.Context, req resource.ReadRequest,
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed reading IPv4 range", err.Error())
+		resp.Diagnostics.AddError("Failed reading IPv4 range", apiErrorDetail(err))
 		return
 	}
 
@@ -155,7 +158,7 @@ func (r *ipv4RangeResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// Read-modify-write: the upsert rebuilds the range from the payload, so
 	// carry the existing site_strategies forward rather than clearing them.
-	current, err := r.client.GetIPv4Range(plan.ID.ValueString())
+	current, err := r.client.GetIPv4Range(ctx, plan.ID.ValueString())
 	if err != nil {
 		if isNotFoundError(err) {
 			resp.Diagnostics.AddAttributeWarning(
@@ -174,7 +177,7 @@ func (r *ipv4RangeResource) Update(ctx context.Context, req resource.UpdateReque
 	current.AssignAddressesFromHere = plan.AssignAddressesFromHere.ValueString()
 	current.SkipFirstNAddresses = plan.SkipFirstNAddresses.ValueInt64()
 
-	out, err := r.client.UpsertIPv4Range(current)
+	out, err := r.client.UpsertIPv4Range(ctx, current)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed updating IPv4 range", err.Error())
 		return
@@ -183,6 +186,7 @@ func (r *ipv4RangeResource) Update(ctx context.Context, req resource.UpdateReque
 	plan.SkipFirstNAddresses = types.Int64Value(out.SkipFirstNAddresses)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *ipv4RangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -191,7 +195,7 @@ func (r *ipv4RangeResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.client.DeleteIPv4Range(state.ID.ValueString()); err != nil {
+	if err := r.client.DeleteIPv4Range(ctx, state.ID.ValueString()); err != nil {
 		if isNotFoundError(err) {
 			return
 		}
@@ -199,6 +203,10 @@ func (r *ipv4RangeResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 }
 
+func (r *ipv4RangeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema(resp)
+}
+
 func (r *ipv4RangeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
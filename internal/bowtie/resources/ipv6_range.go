@@ -16,6 +16,7 @@ import (
 
 var _ resource.Resource = &ipv6RangeResource{}
 var _ resource.ResourceWithImportState = &ipv6RangeResource{}
+var _ resource.ResourceWithIdentity = &ipv6RangeResource{}
 
 type ipv6RangeResource struct {
 	client *client.Client
@@ -82,7 +83,7 @@ func (r *ipv6RangeResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	out, err := r.client.UpsertIPv6Range(&client.OrgIPv6Range{
+	out, err := r.client.UpsertIPv6Range(ctx, &client.OrgIPv6Range{
 		Range:                   plan.Range.ValueString(),
 		AssignAddressesFromHere: plan.AssignAddressesFromHere.ValueBool(),
 	})
@@ -95,6 +96,7 @@ func (r *ipv6RangeResource) Create(ctx context.Context, req resource.CreateReque
 	plan.Range = types.StringValue(out.Range)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *ipv6RangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -103,8 +105,9 @@ func (r *ipv6RangeResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	out, err := r.client.GetIPv6Range(state.ID.ValueString())
+	out, err := r.client.GetIPv6Range(ctx, state.ID.ValueString())
 	if err != nil {
 		if isNotFoundError(err) {
 			resp.Diagnostics.AddAttributeWarning(
@@ -115,7 +118,7 @@ func (r *ipv6RangeResource) Read(ctx context.Context, req resource.ReadRequest,
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed reading IPv6 range", err.Error())
+		resp.Diagnostics.AddError("Failed reading IPv6 range", apiErrorDetail(err))
 		return
 	}
 
@@ -140,7 +143,7 @@ func (r *ipv6RangeResource) Update(ctx context.Context, req resource.UpdateReque
 		rangeValue = plan.Range.ValueString()
 	}
 	if rangeValue == "" {
-		current, err := r.client.GetIPv6Range(plan.ID.ValueString())
+		current, err := r.client.GetIPv6Range(ctx, plan.ID.ValueString())
 		if err != nil {
 			if isNotFoundError(err) {
 				resp.Diagnostics.AddAttributeWarning(
@@ -165,7 +168,7 @@ func (r *ipv6RangeResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	out, err := r.client.UpsertIPv6Range(&client.OrgIPv6Range{
+	out, err := r.client.UpsertIPv6Range(ctx, &client.OrgIPv6Range{
 		ID:                      plan.ID.ValueString(),
 		Range:                   rangeValue,
 		AssignAddressesFromHere: plan.AssignAddressesFromHere.ValueBool(),
@@ -178,6 +181,7 @@ func (r *ipv6RangeResource) Update(ctx context.Context, req resource.UpdateReque
 	plan.Range = types.StringValue(out.Range)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *ipv6RangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -186,7 +190,7 @@ func (r *ipv6RangeResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if err := r.client.DeleteIPv6Range(state.ID.ValueString()); err != nil {
+	if err := r.client.DeleteIPv6Range(ctx, state.ID.ValueString()); err != nil {
 		if isNotFoundError(err) {
 			return
 		}
@@ -194,6 +198,10 @@ func (r *ipv6RangeResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 }
 
+func (r *ipv6RangeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema(resp)
+}
+
 func (r *ipv6RangeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -0,0 +1,84 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// movesFrom reports whether a state move request comes from sourceType of
+// this provider. Other providers may have resource types of the same name.
+func movesFrom(req resource.MoveStateRequest, sourceType string) bool {
+	return req.SourceTypeName == sourceType && strings.HasSuffix(req.SourceProviderAddress, "/bowtie")
+}
+
+// moveStateFrom moves state of sourceType into the target resource in a
+// `moved` block. The source state is first taken through sourceUpgrades, the
+// source resource's own upgrades from the schema version it was stored with,
+// so that move only translates the source's current shape into the target's.
+func moveStateFrom(sourceType string, sourceUpgrades []stateUpgrade, move stateUpgrade) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if !movesFrom(req, sourceType) {
+				return
+			}
+
+			if req.SourceRawState == nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("No %s state was provided to move.", sourceType),
+				)
+				return
+			}
+			version := req.SourceSchemaVersion
+			if version < 0 || version > int64(len(sourceUpgrades)) {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("The %s state has schema version %d, which this release of the provider does not know. Upgrade the provider to move it.", sourceType, version),
+				)
+				return
+			}
+
+			steps := append(append([]stateUpgrade{}, sourceUpgrades[version:]...), move)
+			moved, err := applyStateUpgrades(req.SourceRawState.JSON, steps)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("Could not move the %s state: %s", sourceType, err),
+				)
+				return
+			}
+
+			value, err := tftypes.ValueFromJSONWithOpts(moved, resp.TargetState.Schema.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{
+				IgnoreUndefinedAttributes: true,
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("The state moved from %s does not match the target schema: %s", sourceType, err),
+				)
+				return
+			}
+			resp.TargetState.Raw = value
+		},
+	}
+}
+
+// refuseMoveFrom explains why state of sourceType, which manages a different
+// kind of object, cannot be moved into the target resource.
+func refuseMoveFrom(sourceType, reason string) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if !movesFrom(req, sourceType) {
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Unable to Move Resource State",
+				fmt.Sprintf("%s Remove it from state with a `removed` block and import the target object instead.", reason),
+			)
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// moveStateFixture moves the state fixture of sourceType, written by the given
+// schema version, into r the way Terraform does for a `moved` block: each
+// mover is tried in turn until one sets the state or fails.
+func moveStateFixture(t *testing.T, r resource.ResourceWithMoveState, sourceType string, version int64, fixture string) resource.MoveStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	req := resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/bowtieworks/bowtie",
+		SourceTypeName:        sourceType,
+		SourceSchemaVersion:   version,
+		SourceRawState:        &tfprotov6.RawState{JSON: loadStateFixture(t, fixture)},
+	}
+	resp := resource.MoveStateResponse{}
+	for _, mover := range r.MoveState(ctx) {
+		resp = resource.MoveStateResponse{TargetState: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+		mover.StateMover(ctx, req, &resp)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.Equal(null) {
+			break
+		}
+	}
+	return resp
+}
+
+func TestMoveOrganizationToOrgConfig(t *testing.T) {
+	resp := moveStateFixture(t, &orgConfigResource{}, "bowtie_organization", 0, "bowtie_organization_v0.json")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("moving state: %v", resp.Diagnostics)
+	}
+	assertState(t, resp.TargetState, []byte(`{
+		"id": "organization-config",
+		"last_updated": "Mon, 06 Oct 2025 09:12:44 UTC"
+	}`))
+
+	resp = moveStateFixture(t, &orgConfigResource{}, "bowtie_organization", 7, "bowtie_organization_v0.json")
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error moving state of an unknown schema version")
+	}
+}
+
+func TestMoveRefusesOtherObjects(t *testing.T) {
+	resp := moveStateFixture(t, &siteRangeResource{}, "bowtie_ipv4_range", 0, "bowtie_ipv4_range_v0.json")
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected moving an IPv4 pool into a site range to fail")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "removed") {
+		t.Errorf("error %q does not explain how to proceed", detail)
+	}
+
+	// Types no mover knows are left to the framework to reject.
+	resp = moveStateFixture(t, &orgConfigResource{}, "bowtie_site", 0, "bowtie_site_v0.json")
+	if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+		t.Errorf("unexpected move from bowtie_site: %v", resp.Diagnostics)
+	}
+}
@@ -24,6 +24,7 @@ const orgConfigID = "organization-config"
 var _ resource.Resource = &orgConfigResource{}
 var _ resource.ResourceWithImportState = &orgConfigResource{}
 var _ resource.ResourceWithValidateConfig = &orgConfigResource{}
+var _ resource.ResourceWithMoveState = &orgConfigResource{}
 
 type orgConfigResource struct {
 	client *client.Client
@@ -111,6 +112,9 @@ Import it with ` + "`terraform import bowtie_org_config.this organization-config
 Fields the provider does not surface are preserved on update. Surfaced fields are managed only
 when they are configured or listed in ` + "`clear_fields`" + `; omitting a surfaced field leaves the
 current Control Plane value in place.
+
+Configuration managed as a ` + "`bowtie_organization`" + ` can be moved here with a ` + "`moved`" + ` block
+instead of removing and importing it; the organization's name and domain are then no longer managed.
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -135,8 +139,8 @@ current Control Plane value in place.
 			"controller_ssh_listener":                      computedStringOneOf("Organization default for how Controllers listen on the SSH port: `any`, `local-only`, or `tunnel-only`.", "any", "local-only", "tunnel-only"),
 			"allow_device_approval_on_user_auth":           computedBool("Automatically approve a device when its user authenticates after installing the client."),
 			"allow_controller_approval_with_psk_only":      computedBool("Automatically admit a Controller that presents a valid pre-shared key, for zero-touch cluster scale-out."),
-			"disable_peers_require_quorum":                 computedBool("When false, destructive device cleanup only proceeds when a quorum of Controller peers is connected."),
-			"peers_require_quorum_percentage":              computedInt("Percentage, from 0 through 100, of Controller peers required for quorum. Default 51."),
+			"disable_peers_require_quorum":                 computedBool("When false, destructive device cleanup only proceeds when a quorum of Controller peers is connected. A Controller's `minimum_peers_behavior` overrides it."),
+			"peers_require_quorum_percentage":              computedInt("Percentage, from 0 through 100, of Controller peers required for quorum. Default 51. A Controller's `minimum_peers_behavior` overrides it."),
 			"delete_devices_more_stale_than_days":          computedIntAtLeast("Delete devices that have not checked in for more than this many days. Must be greater than 7.", 8),
 			"remove_approval_devices_more_stale_than_days": computedIntAtLeast("Revoke approval for devices that have not checked in for more than this many days. Must be greater than 7.", 8),
 			"user_device_disassociation_minutes":           computedInt("Disassociate a device from its user after this many minutes."),
@@ -170,9 +174,9 @@ func (r *orgConfigResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	cfg, err := r.client.GetOrgConfig()
+	cfg, err := r.client.GetOrgConfig(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed reading organization configuration", err.Error())
+		resp.Diagnostics.AddError("Failed reading organization configuration", apiErrorDetail(err))
 		return
 	}
 	r.mapToState(cfg, &state)
@@ -186,7 +190,7 @@ func (r *orgConfigResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	cfg, err := r.client.GetOrgConfig()
+	cfg, err := r.client.GetOrgConfig(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed reading organization configuration before update", err.Error())
 		return
@@ -237,7 +241,7 @@ func (r *orgConfigResource) Update(ctx context.Context, req resource.UpdateReque
 		delete(cfg, field)
 	}
 
-	if err := r.client.UpdateOrgConfig(cfg); err != nil {
+	if err := r.client.UpdateOrgConfig(ctx, cfg); err != nil {
 		resp.Diagnostics.AddError("Failed updating organization configuration", err.Error())
 		return
 	}
@@ -254,6 +258,19 @@ func (r *orgConfigResource) Delete(ctx context.Context, req resource.DeleteReque
 	)
 }
 
+// MoveState lets the organization singleton move from bowtie_organization,
+// which can be neither created nor destroyed, to bowtie_org_config. Its name
+// and domain are no longer managed and the configuration is read on the next
+// refresh.
+func (r *orgConfigResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFrom("bowtie_organization", organizationStateUpgrades, func(state map[string]any) error {
+			state["id"] = orgConfigID
+			return nil
+		}),
+	}
+}
+
 func (r *orgConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -320,9 +337,11 @@ func (r *orgConfigResource) mapToState(cfg client.OrgConfig, state *orgConfigRes
 	state.UserDeviceDisassociationMinutes = ocGetInt(cfg, "user_device_disassociation_minutes")
 }
 
-func isSet(v types.String) bool   { return !v.IsNull() && !v.IsUnknown() }
-func isSetBool(v types.Bool) bool { return !v.IsNull() && !v.IsUnknown() }
-func isSetInt(v types.Int64) bool { return !v.IsNull() && !v.IsUnknown() }
+func isSet(v types.String) bool       { return !v.IsNull() && !v.IsUnknown() }
+func isSetBool(v types.Bool) bool     { return !v.IsNull() && !v.IsUnknown() }
+func isSetInt(v types.Int64) bool     { return !v.IsNull() && !v.IsUnknown() }
+func isSetList(v types.List) bool     { return !v.IsNull() && !v.IsUnknown() }
+func isSetObject(v types.Object) bool { return !v.IsNull() && !v.IsUnknown() }
 
 func ocSet(cfg client.OrgConfig, key string, value any) {
 	if b, err := json.Marshal(value); err == nil {
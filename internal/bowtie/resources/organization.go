@@ -16,6 +16,12 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &organizationResource{}
 var _ resource.ResourceWithImportState = &organizationResource{}
+var _ resource.ResourceWithUpgradeState = &organizationResource{}
+
+// organizationStateUpgrades are also applied to state moved from
+// bowtie_organization into bowtie_org_config. The schema has not changed
+// shape yet, so there are none.
+var organizationStateUpgrades []stateUpgrade
 
 type organizationResource struct {
 	client *client.Client
@@ -100,11 +106,11 @@ func (org *organizationResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	org_response, err := org.client.GetOrganization()
+	org_response, err := org.client.GetOrganization(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed retrieving organization information.",
-			err.Error(),
+			apiErrorDetail(err),
 		)
 		return
 	}
@@ -124,6 +130,7 @@ func (org *organizationResource) Update(ctx context.Context, req resource.Update
 	}
 
 	err := org.client.UpsertOrganization(
+		ctx,
 		plan.Name.ValueString(),
 		plan.Domain.ValueString(),
 	)
@@ -145,6 +152,10 @@ func (org *organizationResource) Delete(ctx context.Context, req resource.Delete
 	)
 }
 
+func (org *organizationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return upgradeStates(organizationStateUpgrades...)
+}
+
 func (org *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
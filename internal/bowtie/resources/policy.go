@@ -6,6 +6,7 @@ import (
 
 	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -22,18 +23,20 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &policyResource{}
 var _ resource.ResourceWithImportState = &policyResource{}
+var _ resource.ResourceWithIdentity = &policyResource{}
 
 type policyResource struct {
 	client *client.Client
 }
 
 type policyResourceModel struct {
-	ID     types.String      `tfsdk:"id"`
-	Source policySourceModel `tfsdk:"source"`
-	Dest   types.String      `tfsdk:"dest"`
-	Action types.String      `tfsdk:"action"`
-	Status types.String      `tfsdk:"status"`
-	Order  types.Int64       `tfsdk:"order"`
+	ID       types.String      `tfsdk:"id"`
+	Source   policySourceModel `tfsdk:"source"`
+	Dest     types.String      `tfsdk:"dest"`
+	Action   types.String      `tfsdk:"action"`
+	Status   types.String      `tfsdk:"status"`
+	Order    types.Int64       `tfsdk:"order"`
+	Timeouts timeouts.Value    `tfsdk:"timeouts"`
 }
 
 // maxSourceNestingDepth bounds how deeply and/or/nor groups may nest. The
@@ -246,6 +249,14 @@ Policies are evaluated in order, so set ` + "`order`" + ` explicitly when the re
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -273,29 +284,46 @@ func (p *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	p.upsert(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (p *policyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// Only the id is needed to re-fetch the policy. Decoding the whole model
-	// would fail on import, where prior state has a null source object that
-	// cannot be assigned to the non-nullable policySourceModel field.
+	// Only the id (and the timeouts, which the API does not store) are taken
+	// from prior state. Decoding the whole model would fail on import, where
+	// prior state has a null source object that cannot be assigned to the
+	// non-nullable policySourceModel field.
 	var id types.String
+	var timeoutsValue timeouts.Value
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, id)...)
 
-	policies, err := p.client.GetPoliciesAndResources()
+	ctx, cancel := withOperationTimeout(ctx, timeoutsValue.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := p.client.GetPoliciesAndResources(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to read policy",
-			"Unexpected error reading policy "+id.ValueString()+": "+err.Error(),
+			"Unexpected error reading policy "+id.ValueString()+": "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -319,6 +347,7 @@ func (p *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		)
 		return
 	}
+	model.Timeouts = timeoutsValue
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
@@ -330,12 +359,19 @@ func (p *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx, cancel := withOperationTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	p.upsert(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (p *policyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -345,7 +381,13 @@ func (p *policyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	if err := p.client.DeletePolicy(state.ID.ValueString()); err != nil {
+	ctx, cancel := withOperationTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := p.client.DeletePolicy(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to delete policy",
 			"Unexpected error deleting policy "+state.ID.ValueString()+": "+err.Error(),
@@ -353,8 +395,12 @@ func (p *policyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+func (p *policyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema(resp)
+}
+
 func (p *policyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 // upsert translates the plan into an API call and writes the server-assigned
@@ -388,7 +434,7 @@ func (p *policyResource) upsert(ctx context.Context, plan *policyResourceModel,
 		policy.Order = &order
 	}
 
-	saved, err := p.client.UpsertPolicy(policy)
+	saved, err := p.client.UpsertPolicy(ctx, policy)
 	if err != nil {
 		diags.AddError("Failed to write policy", "Unexpected error writing policy "+plan.ID.ValueString()+": "+err.Error())
 		return
@@ -470,6 +516,13 @@ func assembleSource(m matcherFields, and, or, nor []client.BowtiePolicySource) (
 	return predicate, nil
 }
 
+// SourcePredicate converts one level of a bowtie_policy source, given as its
+// scalar matchers and already converted and/or/nor groups, into a predicate
+// under the same rules the resource applies.
+func SourcePredicate(always, authenticatedUser types.Bool, user, device, userGroup, deviceGroup types.String, and, or, nor []client.BowtiePolicySource) (client.BowtiePredicate, error) {
+	return assembleSource(matcherFields{always, authenticatedUser, user, device, userGroup, deviceGroup}, and, or, nor)
+}
+
 // operandsToSources converts a slice of operands at any nesting level into API
 // sources, tagging each with a fresh ID.
 func operandsToSources[T interface {
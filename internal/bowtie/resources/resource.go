@@ -22,6 +22,7 @@ import (
 var _ resource.Resource = &TemplateResource{}
 var _ resource.ResourceWithImportState = &TemplateResource{}
 var _ resource.ResourceWithValidateConfig = &resourceResource{}
+var _ resource.ResourceWithIdentity = &resourceResource{}
 
 type resourceResource struct {
 	client *client.Client
@@ -205,13 +206,14 @@ func (r *resourceResource) Create(ctx context.Context, req resource.CreateReques
 		plan.ID = types.StringValue(uuid.NewString())
 	}
 
-	location, locationDiags := resourceLocationToClient(plan.Location, r.client.Tagged_locations)
+	location, locationDiags := resourceLocationToClient(plan.Location, r.client.TaggedLocations(ctx))
 	resp.Diagnostics.Append(locationDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	_, err := r.client.UpsertResource(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		plan.Protocol.ValueString(),
@@ -229,6 +231,7 @@ func (r *resourceResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *resourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -237,12 +240,13 @@ func (r *resourceResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	resources, err := r.client.GetResources()
+	resources, err := r.client.GetResources(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unexpected error retrieving the resource",
-			"Failed to retrieve resource: "+state.ID.ValueString()+" error: "+err.Error(),
+			"Failed to retrieve resource: "+state.ID.ValueString()+" error: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -366,13 +370,14 @@ func (r *resourceResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	location, locationDiags := resourceLocationToClient(plan.Location, r.client.Tagged_locations)
+	location, locationDiags := resourceLocationToClient(plan.Location, r.client.TaggedLocations(ctx))
 	resp.Diagnostics.Append(locationDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	_, err := r.client.UpsertResource(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		plan.Protocol.ValueString(),
@@ -390,6 +395,7 @@ func (r *resourceResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *resourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -399,7 +405,7 @@ func (r *resourceResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteResource(plan.ID.ValueString())
+	err := r.client.DeleteResource(ctx, plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"deleting resource failed",
@@ -408,8 +414,12 @@ func (r *resourceResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+func (r *resourceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema(resp)
+}
+
 func (r *resourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func (r *resourceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -469,7 +479,7 @@ func resourceLocationToClient(location *resourceLocationModel, taggedLocations b
 		diags.AddAttributeError(
 			path.Root("location").AtName("collection"),
 			"Collection locations require tagged location format",
-			"location.collection cannot be used when provider tagged_locations is false.",
+			"location.collection cannot be used when provider tagged_locations is false or the Controller still stores resource locations in the legacy format.",
 		)
 		return client.BowtieResourceLocation{}, diags
 	}
@@ -18,6 +18,13 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &resourceGroupResource{}
 var _ resource.ResourceWithImportState = &resourceGroupResource{}
+var _ resource.ResourceWithIdentity = &resourceGroupResource{}
+
+// resourceGroupIdentity lists the attributes that identify a resource group on import
+// in place of its ID.
+var resourceGroupIdentity = []identityAttribute{
+	{name: "name", description: "The name of the resource group."},
+}
 
 type resourceGroupResource struct {
 	client *client.Client
@@ -106,6 +113,7 @@ func (rg *resourceGroupResource) Create(ctx context.Context, req resource.Create
 	}
 
 	err := rg.client.UpsertResourceGroup(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		resources,
@@ -120,6 +128,7 @@ func (rg *resourceGroupResource) Create(ctx context.Context, req resource.Create
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (rg *resourceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -129,12 +138,13 @@ func (rg *resourceGroupResource) Read(ctx context.Context, req resource.ReadRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	resourceGroups, err := rg.client.GetResourceGroups()
+	resourceGroups, err := rg.client.GetResourceGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to read the resource group",
-			"Unexpected error reading the resource group: "+state.ID.ValueString()+" err: "+err.Error(),
+			"Unexpected error reading the resource group: "+state.ID.ValueString()+" err: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -192,6 +202,7 @@ func (rg *resourceGroupResource) Update(ctx context.Context, req resource.Update
 	}
 
 	err := rg.client.UpsertResourceGroup(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		resources,
@@ -207,6 +218,7 @@ func (rg *resourceGroupResource) Update(ctx context.Context, req resource.Update
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (rg *resourceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -216,7 +228,7 @@ func (rg *resourceGroupResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	err := rg.client.DeleteResourceGroup(plan.ID.ValueString())
+	err := rg.client.DeleteResourceGroup(ctx, plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed deleting the resource group",
@@ -225,6 +237,20 @@ func (rg *resourceGroupResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
+func (rg *resourceGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	naturalIdentitySchema(resp, resourceGroupIdentity...)
+}
+
 func (rg *resourceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIdentity(ctx, req, resp, resourceGroupIdentity, func(ctx context.Context, values map[string]string) (string, error) {
+		groups, err := rg.client.GetResourceGroups(ctx)
+		if err != nil {
+			return "", err
+		}
+		names := map[string]string{}
+		for id, group := range groups {
+			names[id] = group.Name
+		}
+		return matchOne("resource group", "name", values["name"], names)
+	})
 }
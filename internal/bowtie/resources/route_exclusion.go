@@ -22,6 +22,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &routeExclusionResource{}
 var _ resource.ResourceWithImportState = &routeExclusionResource{}
+var _ resource.ResourceWithIdentity = &routeExclusionResource{}
 
 type routeExclusionResource struct {
 	client *client.Client
@@ -182,6 +183,7 @@ func (r *routeExclusionResource) Create(ctx context.Context, req resource.Create
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *routeExclusionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -190,10 +192,11 @@ func (r *routeExclusionResource) Read(ctx context.Context, req resource.ReadRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	exclusions, err := r.client.GetRouteExclusions()
+	exclusions, err := r.client.GetRouteExclusions(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read route exclusion", "Unexpected error reading route exclusion "+state.ID.ValueString()+": "+err.Error())
+		resp.Diagnostics.AddError("Failed to read route exclusion", "Unexpected error reading route exclusion "+state.ID.ValueString()+": "+apiErrorDetail(err))
 		return
 	}
 
@@ -230,6 +233,7 @@ func (r *routeExclusionResource) Update(ctx context.Context, req resource.Update
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *routeExclusionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -239,13 +243,17 @@ func (r *routeExclusionResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	if err := r.client.DeleteRouteExclusion(state.ID.ValueString()); err != nil {
+	if err := r.client.DeleteRouteExclusion(ctx, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Failed to delete route exclusion", "Unexpected error deleting route exclusion "+state.ID.ValueString()+": "+err.Error())
 	}
 }
 
+func (r *routeExclusionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema(resp)
+}
+
 func (r *routeExclusionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 // upsert builds the API object from the plan, writes it, and copies back the
@@ -274,7 +282,7 @@ func (r *routeExclusionResource) upsert(ctx context.Context, plan *routeExclusio
 		return
 	}
 
-	saved, err := r.client.UpsertRouteExclusion(exclusion)
+	saved, err := r.client.UpsertRouteExclusion(ctx, exclusion)
 	if err != nil {
 		diags.AddError("Failed to write route exclusion", "Unexpected error writing route exclusion "+plan.ID.ValueString()+": "+err.Error())
 		return
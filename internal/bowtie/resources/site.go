@@ -17,6 +17,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &siteResource{}
 var _ resource.ResourceWithImportState = &siteResource{}
+var _ resource.ResourceWithIdentity = &siteResource{}
 
 type siteResource struct {
 	client *client.Client
@@ -90,7 +91,7 @@ func (s *siteResource) Create(ctx context.Context, req resource.CreateRequest, r
 		plan.ID = types.StringValue(uuid.NewString())
 	}
 
-	err := s.client.CreateSite(plan.ID.ValueString(), plan.Name.ValueString())
+	err := s.client.CreateSite(ctx, plan.ID.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed creating site",
@@ -102,6 +103,7 @@ func (s *siteResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (s *siteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -110,12 +112,13 @@ func (s *siteResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	sites, err := s.client.GetSites()
+	sites, err := s.client.GetSites(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed retrieving site information from bowtie",
-			"Unexpected error retrieving site info from bowtie server: "+err.Error(),
+			"Unexpected error retrieving site info from bowtie server: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -143,7 +146,7 @@ func (s *siteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	err := s.client.UpsertSite(plan.ID.ValueString(), plan.Name.ValueString())
+	err := s.client.UpsertSite(ctx, plan.ID.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed updating the site",
@@ -155,6 +158,7 @@ func (s *siteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (s *siteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -165,7 +169,7 @@ func (s *siteResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := s.client.DeleteSite(state.ID.ValueString())
+	err := s.client.DeleteSite(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed deleting the site",
@@ -174,6 +178,10 @@ func (s *siteResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+func (s *siteResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	idIdentitySchema(resp)
+}
+
 func (s *siteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -9,18 +9,29 @@ import (
 	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &siteRangeResource{}
 var _ resource.ResourceWithImportState = &siteRangeResource{}
+var _ resource.ResourceWithIdentity = &siteRangeResource{}
+var _ resource.ResourceWithMoveState = &siteRangeResource{}
+
+// siteRangeIdentity lists the attributes that identify a range within its
+// site on import in place of its ID.
+var siteRangeIdentity = []identityAttribute{
+	{name: "name", description: "The human readable name of the range."},
+}
 
 type siteRangeResource struct {
 	client *client.Client
@@ -158,6 +169,7 @@ func (sr *siteRangeResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	err := sr.client.UpsertSiteRange(
+		ctx,
 		plan.SiteID.ValueString(),
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
@@ -180,6 +192,7 @@ func (sr *siteRangeResource) Create(ctx context.Context, req resource.CreateRequ
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setSiteRangeIdentity(ctx, resp.Identity, plan)...)
 }
 
 func (sr *siteRangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -188,22 +201,26 @@ func (sr *siteRangeResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setSiteRangeIdentity(ctx, resp.Identity, state)...)
 
-	sites, err := sr.client.GetSites()
+	sites, err := sr.client.GetSites(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed retrieving site information from bowtie",
-			"Unexpected error retrieving site info from bowtie server: "+err.Error(),
+			"Unexpected error retrieving site info from bowtie server: "+apiErrorDetail(err),
 		)
 		return
 	}
 
 	site, err := client.FindSite(state.SiteID.ValueString(), sites)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed retrieving site range from bowtie",
-			"Unexpected error retrieving site range info from bowtie server: "+err.Error(),
+		// Deleting a site deletes its ranges along with it.
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("site_id"),
+			"site not found, removing range from state",
+			state.SiteID.ValueString(),
 		)
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -250,7 +267,7 @@ func (sr *siteRangeResource) Update(ctx context.Context, req resource.UpdateRequ
 		cidr = plan.IPV6Range.ValueString()
 	}
 
-	err := sr.client.UpsertSiteRange(plan.SiteID.ValueString(), plan.ID.ValueString(), plan.Name.ValueString(), plan.Description.ValueString(), cidr, is_ipv4, is_ipv6, plan.Weight.ValueInt64(), plan.Metric.ValueInt64())
+	err := sr.client.UpsertSiteRange(ctx, plan.SiteID.ValueString(), plan.ID.ValueString(), plan.Name.ValueString(), plan.Description.ValueString(), cidr, is_ipv4, is_ipv6, plan.Weight.ValueInt64(), plan.Metric.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed updating site range info",
@@ -262,6 +279,7 @@ func (sr *siteRangeResource) Update(ctx context.Context, req resource.UpdateRequ
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setSiteRangeIdentity(ctx, resp.Identity, plan)...)
 }
 
 func (sr *siteRangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -271,7 +289,7 @@ func (sr *siteRangeResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := sr.client.DeleteSiteRange(state.SiteID.ValueString(), state.ID.ValueString())
+	err := sr.client.DeleteSiteRange(ctx, state.SiteID.ValueString(), state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed deleting site range",
@@ -280,7 +298,49 @@ func (sr *siteRangeResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+func (sr *siteRangeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	naturalIdentitySchema(resp, siteRangeIdentity...)
+	resp.IdentitySchema.Attributes["site_id"] = identityschema.StringAttribute{
+		RequiredForImport: true,
+		Description:       "The Site ID that the range is associated with.",
+	}
+}
+
+// MoveState turns away moves from bowtie_ipv4_range, whose organization
+// address pools look alike but are a different object on the Controller.
+func (sr *siteRangeResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		refuseMoveFrom("bowtie_ipv4_range", "A bowtie_ipv4_range is an organization address pool that devices are assigned addresses from, not a range routed through a site."),
+	}
+}
+
 func (sr *siteRangeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && resp.Identity != nil {
+		var siteID types.String
+		resp.Diagnostics.Append(resp.Identity.GetAttribute(ctx, path.Root("site_id"), &siteID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site_id"), siteID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		importByIdentity(ctx, req, resp, siteRangeIdentity, func(ctx context.Context, values map[string]string) (string, error) {
+			sites, err := sr.client.GetSites(ctx)
+			if err != nil {
+				return "", err
+			}
+			site, err := client.FindSite(siteID.ValueString(), sites)
+			if err != nil {
+				return "", err
+			}
+			names := map[string]string{}
+			for _, r := range append(site.RoutableRangesV4, site.RouteRangesV6...) {
+				names[r.ID] = r.Name
+			}
+			return matchOne("site range", "name", values["name"], names)
+		})
+		return
+	}
+
 	idParts := strings.Split(req.ID, ":")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
@@ -294,3 +354,10 @@ func (sr *siteRangeResource) ImportState(ctx context.Context, req resource.Impor
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site_id"), idParts[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
 }
+
+// setSiteRangeIdentity records the site and ID of the range as its identity.
+func setSiteRangeIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, model siteRangeResourceModel) diag.Diagnostics {
+	diags := setIdentity(ctx, identity, "site_id", model.SiteID)
+	diags.Append(setIDIdentity(ctx, identity, model.ID)...)
+	return diags
+}
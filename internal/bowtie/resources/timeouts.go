@@ -0,0 +1,21 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// defaultOperationTimeout bounds an operation on a resource with a timeouts
+// block when the configuration does not set one for it.
+const defaultOperationTimeout = 20 * time.Minute
+
+// withOperationTimeout bounds ctx by the timeout configured for one operation,
+// such as plan.Timeouts.Create. The bound covers every request the operation
+// makes, including retries.
+func withOperationTimeout(ctx context.Context, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	d, timeoutDiags := timeout(ctx, defaultOperationTimeout)
+	diags.Append(timeoutDiags...)
+	return context.WithTimeout(ctx, d)
+}
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestSlowResourcesHaveTimeoutsBlock(t *testing.T) {
+	ctx := context.Background()
+	for name, res := range map[string]resource.Resource{
+		"bowtie_controller":     &controllerResource{},
+		"bowtie_dns_block_list": &dnsBlockListResource{},
+		"bowtie_collection":     &collectionResource{},
+		"bowtie_policy":         &policyResource{},
+	} {
+		resp := &resource.SchemaResponse{}
+		res.Schema(ctx, resource.SchemaRequest{}, resp)
+
+		if _, ok := resp.Schema.Blocks["timeouts"]; !ok {
+			t.Errorf("%s has no timeouts block", name)
+		}
+		if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+			t.Errorf("%s schema is invalid: %v", name, diags)
+		}
+	}
+}
+
+func TestWithOperationTimeoutBoundsContext(t *testing.T) {
+	var diags diag.Diagnostics
+	configured := func(context.Context, time.Duration) (time.Duration, diag.Diagnostics) {
+		return time.Minute, nil
+	}
+
+	ctx, cancel := withOperationTimeout(context.Background(), configured, &diags)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected the context to carry a deadline")
+	}
+	if remaining := time.Until(deadline); remaining > time.Minute || remaining < 50*time.Second {
+		t.Errorf("deadline in %s, want about a minute", remaining)
+	}
+	if diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stateUpgrade rewrites state of one schema version, decoded from its JSON
+// form, into the shape of the next version. Attributes left over once the
+// last upgrade has run are dropped, so removing an attribute needs no upgrade
+// of its own.
+type stateUpgrade func(state map[string]any) error
+
+// upgradeStates builds a resource's UpgradeState map from the upgrades
+// between successive schema versions: upgrades[v] turns version v state into
+// version v+1, and the resource's schema version is len(upgrades). State of
+// any older version is taken through every later upgrade in turn, so each
+// upgrade only describes the one change it was written for, and no prior
+// schema has to be kept around.
+func upgradeStates(upgrades ...stateUpgrade) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(upgrades))
+	for version := range upgrades {
+		remaining := upgrades[version:]
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("No prior state was provided to upgrade from schema version %d.", version),
+					)
+					return
+				}
+
+				upgraded, err := applyStateUpgrades(req.RawState.JSON, remaining)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Could not upgrade state from schema version %d: %s", version, err),
+					)
+					return
+				}
+
+				value, err := tftypes.ValueFromJSONWithOpts(upgraded, resp.State.Schema.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{
+					IgnoreUndefinedAttributes: true,
+				})
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("The state upgraded from schema version %d does not match the current schema: %s", version, err),
+					)
+					return
+				}
+				resp.State.Raw = value
+			},
+		}
+	}
+	return upgraders
+}
+
+func applyStateUpgrades(raw []byte, upgrades []stateUpgrade) ([]byte, error) {
+	var state map[string]any
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	for _, upgrade := range upgrades {
+		if err := upgrade(state); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(state)
+}
@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// managedResources are all the managed resources, each of which must be able
+// to upgrade state from every earlier schema version.
+var managedResources = map[string]resource.Resource{
+	"bowtie_collection":              &collectionResource{},
+	"bowtie_controller":              &controllerResource{},
+	"bowtie_device":                  &deviceResource{},
+	"bowtie_device_group":            &deviceGroupResource{},
+	"bowtie_device_group_membership": &deviceGroupMembershipResource{},
+	"bowtie_dns":                     &dnsResource{},
+	"bowtie_dns_block_list":          &dnsBlockListResource{},
+	"bowtie_group":                   &groupResource{},
+	"bowtie_group_membership":        &GroupMembershipResource{},
+	"bowtie_ipv4_range":              &ipv4RangeResource{},
+	"bowtie_ipv6_range":              &ipv6RangeResource{},
+	"bowtie_org_config":              &orgConfigResource{},
+	"bowtie_organization":            &organizationResource{},
+	"bowtie_policy":                  &policyResource{},
+	"bowtie_resource":                &resourceResource{},
+	"bowtie_resource_group":          &resourceGroupResource{},
+	"bowtie_route_exclusion":         &routeExclusionResource{},
+	"bowtie_site":                    &siteResource{},
+	"bowtie_site_range":              &siteRangeResource{},
+	"bowtie_user":                    &UserResource{},
+}
+
+// loadStateFixture reads testdata/state/<name>, the attributes of a resource
+// as Terraform stored them in state. <type>_v<N>.json holds state written by
+// schema version N.
+func loadStateFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "state", name))
+	if err != nil {
+		t.Fatalf("loading state fixture: %v", err)
+	}
+	return raw
+}
+
+func runStateUpgrade(t *testing.T, upgraders map[int64]resource.StateUpgrader, s schema.Schema, version int64, raw []byte) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	upgrader, ok := upgraders[version]
+	if !ok {
+		t.Fatalf("no state upgrader for schema version %d", version)
+	}
+
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrading state from schema version %d: %v", version, resp.Diagnostics)
+	}
+	return resp.State
+}
+
+// assertState checks that state holds exactly the attributes in want, given in
+// the same JSON form as the fixtures. Attributes want leaves out must be null.
+func assertState(t *testing.T, state tfsdk.State, want []byte) {
+	t.Helper()
+	ctx := context.Background()
+
+	wantValue, err := tftypes.ValueFromJSONWithOpts(want, state.Schema.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{})
+	if err != nil {
+		t.Fatalf("decoding expected state: %v", err)
+	}
+	if state.Raw.Equal(wantValue) {
+		return
+	}
+
+	diffs, err := state.Raw.Diff(wantValue)
+	if err != nil {
+		t.Fatalf("comparing states: %v", err)
+	}
+	for _, diff := range diffs {
+		t.Errorf("%s: got %v, want %v", diff.Path, diff.Value1, diff.Value2)
+	}
+}
+
+func TestResourcesUpgradeEverySchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	for name, res := range managedResources {
+		resp := resource.SchemaResponse{}
+		res.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+		var upgraders map[int64]resource.StateUpgrader
+		if upgradable, ok := res.(resource.ResourceWithUpgradeState); ok {
+			upgraders = upgradable.UpgradeState(ctx)
+		}
+		for version := int64(0); version < resp.Schema.Version; version++ {
+			if _, ok := upgraders[version]; !ok {
+				t.Errorf("%s cannot upgrade state from schema version %d", name, version)
+			}
+		}
+		if len(upgraders) != int(resp.Schema.Version) {
+			t.Errorf("%s has %d state upgraders for schema version %d", name, len(upgraders), resp.Schema.Version)
+		}
+	}
+}
+
+func TestUpgradeStatesAppliesLaterUpgradesInTurn(t *testing.T) {
+	// A schema whose version 1 renamed "server" to "servers" and version 2
+	// made it a list.
+	upgraders := upgradeStates(
+		func(state map[string]any) error {
+			state["servers"] = state["server"]
+			return nil
+		},
+		func(state map[string]any) error {
+			state["servers"] = []any{state["servers"]}
+			return nil
+		},
+	)
+	s := schema.Schema{
+		Version: 2,
+		Attributes: map[string]schema.Attribute{
+			"id":      schema.StringAttribute{Computed: true},
+			"servers": schema.ListAttribute{ElementType: types.StringType, Optional: true},
+		},
+	}
+
+	v0 := runStateUpgrade(t, upgraders, s, 0, []byte(`{"id": "1", "server": "10.0.0.53"}`))
+	assertState(t, v0, []byte(`{"id": "1", "servers": ["10.0.0.53"]}`))
+
+	v1 := runStateUpgrade(t, upgraders, s, 1, []byte(`{"id": "1", "servers": "10.0.1.53"}`))
+	assertState(t, v1, []byte(`{"id": "1", "servers": ["10.0.1.53"]}`))
+}
@@ -21,6 +21,13 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &TemplateResource{}
+var _ resource.ResourceWithIdentity = &UserResource{}
+
+// userIdentity lists the attributes that identify a user on import
+// in place of its ID.
+var userIdentity = []identityAttribute{
+	{name: "email", description: "Identifying login address."},
+}
 
 type UserResource struct {
 	client *client.Client
@@ -148,6 +155,7 @@ func (u *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	_, err := u.client.UpsertUser(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		plan.Email.ValueString(),
@@ -168,6 +176,7 @@ func (u *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (u *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -176,12 +185,22 @@ func (u *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 
-	user, err := u.client.GetUser(state.ID.ValueString())
+	user, err := u.client.GetUser(ctx, state.ID.ValueString())
+	if isNotFoundError(err) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("id"),
+			"User not found, removing from state",
+			state.ID.ValueString(),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed reading the user: "+state.ID.ValueString(),
-			"Unexpected error reading the user: "+err.Error(),
+			"Unexpected error reading the user: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -208,6 +227,7 @@ func (u *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	_, err := u.client.UpsertUser(
+		ctx,
 		plan.ID.ValueString(),
 		plan.Name.ValueString(),
 		plan.Email.ValueString(),
@@ -227,6 +247,7 @@ func (u *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (u *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -245,6 +266,16 @@ func (u *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+func (u *UserResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	naturalIdentitySchema(resp, userIdentity...)
+}
+
 func (u *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIdentity(ctx, req, resp, userIdentity, func(ctx context.Context, values map[string]string) (string, error) {
+		user, err := u.client.GetUserByEmail(ctx, values["email"])
+		if err != nil {
+			return "", err
+		}
+		return user.ID, nil
+	})
 }
@@ -2,6 +2,8 @@ package resources
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -147,14 +149,124 @@ func TestTaggedValueToStateDropsStaleValueForNoValueVariant(t *testing.T) {
 	}
 }
 
+func TestValidateBackupStrategies(t *testing.T) {
+	ctx := context.Background()
+	elementType := types.ObjectType{AttrTypes: controllerBackupStrategyAttrTypes}
+
+	for name, tc := range map[string]struct {
+		strategy controllerBackupStrategyModel
+		wantPath string
+	}{
+		"s3 bucket": {
+			strategy: controllerBackupStrategyModel{Type: types.StringValue("s3"), Bucket: types.StringValue("dr-backups"), Region: types.StringValue("us-east-1")},
+		},
+		"directory path": {
+			strategy: controllerBackupStrategyModel{Type: types.StringValue("directory"), Path: types.StringValue("/var/backups/bowtie")},
+		},
+		"s3 without bucket": {
+			strategy: controllerBackupStrategyModel{Type: types.StringValue("s3")},
+			wantPath: "backup_strategies[0].bucket",
+		},
+		"directory with bucket": {
+			strategy: controllerBackupStrategyModel{Type: types.StringValue("directory"), Path: types.StringValue("/var/backups"), Bucket: types.StringValue("dr-backups")},
+			wantPath: "backup_strategies[0].bucket",
+		},
+	} {
+		list, diags := types.ListValueFrom(ctx, elementType, []controllerBackupStrategyModel{tc.strategy})
+		if diags.HasError() {
+			t.Fatalf("%s: %v", name, diags)
+		}
+
+		validateBackupStrategies(ctx, list, path.Root("backup_strategies"), &diags)
+		switch {
+		case tc.wantPath == "" && diags.HasError():
+			t.Errorf("%s: unexpected diagnostics: %v", name, diags)
+		case tc.wantPath != "" && (!diags.HasError() || diags.Errors()[0].(diag.DiagnosticWithPath).Path().String() != tc.wantPath):
+			t.Errorf("%s: expected an error at %s, got %v", name, tc.wantPath, diags)
+		}
+	}
+}
+
+func TestValidateMinimumPeers(t *testing.T) {
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		model   controllerMinimumPeersModel
+		wantErr bool
+	}{
+		"percentage":              {model: controllerMinimumPeersModel{Type: types.StringValue("percentage"), Percentage: types.Int64Value(60)}},
+		"disabled":                {model: controllerMinimumPeersModel{Type: types.StringValue("disabled"), Percentage: types.Int64Null()}},
+		"percentage without one":  {model: controllerMinimumPeersModel{Type: types.StringValue("percentage"), Percentage: types.Int64Null()}, wantErr: true},
+		"disabled with a percent": {model: controllerMinimumPeersModel{Type: types.StringValue("disabled"), Percentage: types.Int64Value(60)}, wantErr: true},
+	} {
+		object, diags := types.ObjectValueFrom(ctx, controllerMinimumPeersAttrTypes, tc.model)
+		if diags.HasError() {
+			t.Fatalf("%s: %v", name, diags)
+		}
+		validateMinimumPeers(ctx, object, path.Root("minimum_peers_behavior"), &diags)
+		if diags.HasError() != tc.wantErr {
+			t.Errorf("%s: got diagnostics %v, want error %t", name, diags, tc.wantErr)
+		}
+	}
+}
+
+func TestBackupStrategiesRoundTripThroughState(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	if list := backupStrategiesToState(ctx, nil, &diags); !list.IsNull() {
+		t.Fatalf("state for no override = %v, want null", list)
+	}
+	empty := backupStrategiesFromPlan(ctx, backupStrategiesToState(ctx, []client.BackupStrategy{}, &diags), nil, &diags)
+	if empty == nil || len(empty) != 0 {
+		t.Fatalf("an empty list became %#v, want an empty non-nil slice so backups are turned off", empty)
+	}
+
+	strategies := []client.BackupStrategy{
+		{Type: "s3", Value: client.BackupLocation{Bucket: stringPointer("dr-backups"), Prefix: stringPointer("bowtie/")}},
+		{Type: "directory", Value: client.BackupLocation{Path: stringPointer("/var/backups/bowtie")}},
+	}
+	got := backupStrategiesFromPlan(ctx, backupStrategiesToState(ctx, strategies, &diags), nil, &diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(got) != 2 || got[0].Type != "s3" || *got[0].Value.Bucket != "dr-backups" || *got[0].Value.Prefix != "bowtie/" ||
+		got[0].Value.Path != nil || got[1].Type != "directory" || *got[1].Value.Path != "/var/backups/bowtie" {
+		t.Fatalf("round-tripped strategies = %#v", got)
+	}
+}
+
 func TestIsNotFoundError(t *testing.T) {
-	for _, msg := range []string{"HTTP 404 Not Found: missing", "ipv6 range not found: id"} {
-		if !isNotFoundError(errString(msg)) {
-			t.Fatalf("expected %q to be treated as not found", msg)
+	for _, err := range []error{
+		&client.APIError{StatusCode: http.StatusNotFound},
+		fmt.Errorf("ipv6 range id: %w", client.ErrNotFound),
+	} {
+		if !isNotFoundError(err) {
+			t.Fatalf("expected %q to be treated as not found", err)
+		}
+	}
+	for _, err := range []error{
+		nil,
+		&client.APIError{StatusCode: http.StatusInternalServerError, Body: "resource not found in cache"},
+		errString("HTTP 404 Not Found: missing"),
+	} {
+		if isNotFoundError(err) {
+			t.Fatalf("expected %v not to be treated as not found", err)
 		}
 	}
 }
 
+func TestAPIErrorDetailAddsHintForForbidden(t *testing.T) {
+	detail := apiErrorDetail(&client.APIError{StatusCode: http.StatusForbidden, Method: "GET", Path: "/-net/api/v0/policy"})
+	if !strings.Contains(detail, "HTTP 403") || !strings.Contains(detail, "not permitted") {
+		t.Fatalf("unexpected detail: %q", detail)
+	}
+
+	plain := apiErrorDetail(errString("connection refused"))
+	if plain != "connection refused" {
+		t.Fatalf("unexpected detail for an untyped error: %q", plain)
+	}
+}
+
 func stringPointer(s string) *string {
 	return &s
 }
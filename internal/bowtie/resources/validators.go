@@ -2,10 +2,12 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/netip"
 	"strings"
 
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/client"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -33,8 +35,7 @@ func (v cidrPrefixValidator) ValidateString(ctx context.Context, req validator.S
 		return
 	}
 
-	prefix, err := netip.ParsePrefix(req.ConfigValue.ValueString())
-	if err != nil {
+	if _, err := netip.ParsePrefix(req.ConfigValue.ValueString()); err != nil {
 		resp.Diagnostics.AddAttributeError(
 			req.Path,
 			"Invalid CIDR prefix",
@@ -43,16 +44,66 @@ func (v cidrPrefixValidator) ValidateString(ctx context.Context, req validator.S
 		return
 	}
 
-	switch v.version {
-	case 4:
-		if !prefix.Addr().Is4() {
-			resp.Diagnostics.AddAttributeError(req.Path, "Invalid IPv4 CIDR prefix", "Value must be an IPv4 CIDR prefix.")
+	if _, err := ParseCIDRPrefix(req.ConfigValue.ValueString(), v.version); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, fmt.Sprintf("Invalid IPv%d CIDR prefix", v.version), fmt.Sprintf("Value must be an IPv%d CIDR prefix.", v.version))
+	}
+}
+
+// ParseCIDRPrefix parses a CIDR prefix of the given IP version, or of either
+// version when version is 0.
+func ParseCIDRPrefix(value string, version int) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	switch {
+	case version == 4 && !prefix.Addr().Is4():
+		return netip.Prefix{}, fmt.Errorf("%q is not an IPv4 CIDR prefix", value)
+	case version == 6 && !prefix.Addr().Is6():
+		return netip.Prefix{}, fmt.Errorf("%q is not an IPv6 CIDR prefix", value)
+	}
+	return prefix, nil
+}
+
+// InferLocationType reports which kind of location value is: "ip" for a
+// single address, "cidr" for a prefix, or "dns" for a hostname, which may
+// start with a "*." wildcard label.
+func InferLocationType(value string) (string, error) {
+	if _, err := netip.ParseAddr(value); err == nil {
+		return "ip", nil
+	}
+	if _, err := ParseCIDRPrefix(value, 0); err == nil {
+		return "cidr", nil
+	}
+	if isDNSName(value) {
+		return "dns", nil
+	}
+	return "", fmt.Errorf("%q is not an IP address, CIDR prefix or DNS name", value)
+}
+
+func isDNSName(value string) bool {
+	name := strings.TrimSuffix(strings.TrimPrefix(value, "*."), ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+
+	labels := strings.Split(name, ".")
+	// A name whose last label is numeric is a mistyped address, not a host.
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
 		}
-	case 6:
-		if !prefix.Addr().Is6() {
-			resp.Diagnostics.AddAttributeError(req.Path, "Invalid IPv6 CIDR prefix", "Value must be an IPv6 CIDR prefix.")
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return false
+			}
 		}
 	}
+	return true
 }
 
 func stringSetToMap(ctx context.Context, value types.Set, attrPath path.Path, allowed map[string]struct{}, diags *diag.Diagnostics) map[string]bool {
@@ -95,11 +146,23 @@ func validateClearConflicts(ctx context.Context, value types.Set, attrPath path.
 }
 
 func isNotFoundError(err error) bool {
-	if err == nil {
-		return false
+	return errors.Is(err, client.ErrNotFound)
+}
+
+// apiErrorDetail renders a client error for a diagnostic. Failures the user can
+// act on, such as rejected credentials or a missing permission, are followed
+// by a hint on what to change.
+func apiErrorDetail(err error) string {
+	detail := err.Error()
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		detail += "\n\nThe Controller rejected the provider's credentials. Check the configured username and password (or api_token) and that the account is still enabled."
+	case errors.Is(err, client.ErrForbidden):
+		detail += "\n\nThe account Terraform authenticates as is not permitted to perform this operation. Grant it the administrator permission for this area of the Controller, or remove the resource from this configuration."
+	case errors.Is(err, client.ErrConflict):
+		detail += "\n\nThe Controller reported a conflict with the object's current state, for example a duplicate name or a concurrent change. Refresh and review the object before applying again."
 	}
-	msg := strings.ToLower(err.Error())
-	return strings.Contains(msg, "http 404") || strings.Contains(msg, "not found")
+	return detail
 }
 
 type taggedValueValueModifier struct {
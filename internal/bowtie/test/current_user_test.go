@@ -0,0 +1,24 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/provider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCurrentUserDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "bowtie_current_user" "me" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.bowtie_current_user.me", "id"),
+					resource.TestCheckResourceAttrSet("data.bowtie_current_user.me", "email"),
+					resource.TestCheckResourceAttrSet("data.bowtie_current_user.me", "role"),
+				),
+			},
+		},
+	})
+}
@@ -1,6 +1,8 @@
 package test
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"text/template"
@@ -8,6 +10,7 @@ import (
 	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/provider"
 	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/utils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 const (
@@ -28,12 +31,13 @@ func TestDNSBlockListResource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Basic tests for upstream URLs
 			{
-				Config: getDNSBlockListConfig(blResourceName, blName, blUrl, blOverride),
+				Config: getDNSBlockListConfig(blResourceName, blName, blUrl, blOverride, false),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(blResourceName, "name", blName),
 					resource.TestCheckResourceAttr(blResourceName, "upstream", blUrl),
 					resource.TestCheckResourceAttr(blResourceName, "override_to_allow.0", blOverride[0]),
 					resource.TestCheckResourceAttr(blResourceName, "override_to_allow.1", blOverride[1]),
+					resource.TestCheckResourceAttr(blResourceName, "is_allowlist", "false"),
 					resource.TestCheckResourceAttrSet(blResourceName, "id"),
 					resource.TestCheckResourceAttrSet(blResourceName, "last_updated"),
 				),
@@ -49,7 +53,7 @@ func TestDNSBlockListResource(t *testing.T) {
 			},
 			// Update and Read testing
 			{
-				Config: getDNSBlockListConfig(blResourceName, blNameChange, blUrlChange, blOverrideChange),
+				Config: getDNSBlockListConfig(blResourceName, blNameChange, blUrlChange, blOverrideChange, false),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(blResourceName, "name", blNameChange),
 					resource.TestCheckResourceAttr(blResourceName, "upstream", blUrlChange),
@@ -64,27 +68,105 @@ func TestDNSBlockListResource(t *testing.T) {
 	})
 }
 
+func TestDNSBlockListAllowlist(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getDNSBlockListConfig(blResourceName, blName, blUrl, blOverride, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(blResourceName, "is_allowlist", "true"),
+					checkDNSBlockListIsAllowlist(blResourceName, true),
+				),
+			},
+			// ImportState reads the mode back from the API
+			{
+				ResourceName:            blResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// A list flipped outside of Terraform shows up as drift
+			{
+				PreConfig:          func() { setDNSBlockListsIsAllowlist(blName, false) },
+				Config:             getDNSBlockListConfig(blResourceName, blName, blUrl, blOverride, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Dropping the attribute turns the list back into a block list
+			{
+				Config: getDNSBlockListConfig(blResourceName, blName, blUrl, blOverride, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(blResourceName, "is_allowlist", "false"),
+					checkDNSBlockListIsAllowlist(blResourceName, false),
+				),
+			},
+		},
+	})
+}
+
+// checkDNSBlockListIsAllowlist checks the mode the API holds for a list.
+func checkDNSBlockListIsAllowlist(resourceName string, want bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		client, err := utils.NewEnvClient()
+		if err != nil {
+			return err
+		}
+		blocklists, err := client.GetDNSBlockLists(context.Background())
+		if err != nil {
+			return err
+		}
+		blocklist, ok := blocklists[rs.Primary.ID]
+		if !ok {
+			return fmt.Errorf("DNS block list %s not found", rs.Primary.ID)
+		}
+		if blocklist.IsAllowlist != want {
+			return fmt.Errorf("is_allowlist = %t in the API, want %t", blocklist.IsAllowlist, want)
+		}
+		return nil
+	}
+}
+
+// Change the mode of the named DNS block lists behind Terraform's back.
+func setDNSBlockListsIsAllowlist(name string, isAllowlist bool) {
+	ctx := context.Background()
+	client, _ := utils.NewEnvClient()
+
+	blocklists, _ := client.GetDNSBlockLists(ctx)
+	for id, blocklist := range blocklists {
+		if blocklist.Name == name {
+			_ = client.UpsertDNSBlockList(ctx, id, blocklist.Name, blocklist.Upstream, blocklist.OverrideToAllow, isAllowlist)
+		}
+	}
+}
+
 func TestAccDNSBlockListResourceRecreation(t *testing.T) {
 	utils.RecreationTest(
 		t,
 		blResourceName,
-		getDNSBlockListConfig(blResourceName, blName, blUrl, blOverride),
+		getDNSBlockListConfig(blResourceName, blName, blUrl, blOverride, false),
 		deleteDNSBlockListResources,
 	)
 }
 
 // Delete all DNS blocklist resources from the API.
 func deleteDNSBlockListResources() {
+	ctx := context.Background()
 	client, _ := utils.NewEnvClient()
 
 	// Pretty simple blanket statement to just remove everything.
-	blocklists, _ := client.GetDNSBlockLists()
+	blocklists, _ := client.GetDNSBlockLists(ctx)
 	for id := range blocklists {
-		_ = client.DeleteDNSBlockList(id)
+		_ = client.DeleteDNSBlockList(ctx, id)
 	}
 }
 
-func getDNSBlockListConfig(resource string, name string, url string, overrides []string) string {
+func getDNSBlockListConfig(resource string, name string, url string, overrides []string, isAllowlist bool) string {
 	funcMap := template.FuncMap{
 		"notNil": func(val any) bool {
 			return val != nil
@@ -98,11 +180,12 @@ func getDNSBlockListConfig(resource string, name string, url string, overrides [
 
 	var output *strings.Builder = &strings.Builder{}
 	err = tmpl.ExecuteTemplate(output, "dns_block_list.tmpl", map[string]interface{}{
-		"provider":  provider.ProviderConfig,
-		"resource":  strings.Split(resource, ".")[1],
-		"name":      name,
-		"upstream":  url,
-		"overrides": overrides,
+		"provider":     provider.ProviderConfig,
+		"resource":     strings.Split(resource, ".")[1],
+		"name":         name,
+		"upstream":     url,
+		"overrides":    overrides,
+		"is_allowlist": isAllowlist,
 	})
 
 	if err != nil {
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"text/template"
@@ -221,12 +222,13 @@ func TestAccDNSResourceRecreation(t *testing.T) {
 
 // Delete all DNS resources from the API.
 func deleteDNSResources() {
+	ctx := context.Background()
 	client, _ := utils.NewEnvClient()
 
 	// Pretty simple blanket statement to just remove everything.
-	dnss, _ := client.GetDNS()
+	dnss, _ := client.GetDNS(ctx)
 	for id := range dnss {
-		_ = client.DeleteDNS(id)
+		_ = client.DeleteDNS(ctx, id)
 	}
 }
 
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"text/template"
@@ -63,12 +64,13 @@ func TestAccGroupRecreation(t *testing.T) {
 
 // Delete all group resources from the API.
 func deleteGroupResources() {
+	ctx := context.Background()
 	client, _ := utils.NewEnvClient()
 
 	// Pretty simple blanket statement to just remove everything.
-	groups, _ := client.GetGroups()
+	groups, _ := client.GetGroups(ctx)
 	for id := range groups {
-		_ = client.DeleteGroup(id)
+		_ = client.DeleteGroup(ctx, id)
 	}
 }
 
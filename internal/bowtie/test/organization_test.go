@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"text/template"
@@ -75,13 +76,14 @@ func getOrganizationConfig(resource string, name string, domain string) string {
 
 func getOrgId() resource.ImportStateIdFunc {
 	return func(state *terraform.State) (string, error) {
+		ctx := context.Background()
 		client, err := utils.NewEnvClient()
 
 		if err != nil {
 			return "", err
 		}
 
-		org, err := client.GetOrganization()
+		org, err := client.GetOrganization(ctx)
 		if err != nil {
 			return "", err
 		}
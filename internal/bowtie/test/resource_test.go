@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"text/template"
@@ -92,12 +93,13 @@ func TestAccBowtieResourceRecreation(t *testing.T) {
 
 // Delete all Bowtie resources from the API.
 func deleteBowtieResources() {
+	ctx := context.Background()
 	client, _ := utils.NewEnvClient()
 
 	// Pretty simple blanket statement to just remove everything.
-	resources, _ := client.GetResources()
+	resources, _ := client.GetResources(ctx)
 	for id := range resources {
-		_ = client.DeleteResource(id)
+		_ = client.DeleteResource(ctx, id)
 	}
 }
 
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -134,11 +135,12 @@ func TestAccIPv4RangeOutOfBandDeleteRecreates(t *testing.T) {
 					if ipv4RangeID == "" {
 						t.Fatal("missing IPv4 range ID from first apply")
 					}
+					ctx := context.Background()
 					c, err := utils.NewEnvClient()
 					if err != nil {
 						t.Fatalf("new env client: %v", err)
 					}
-					if err := c.DeleteIPv4Range(ipv4RangeID); err != nil {
+					if err := c.DeleteIPv4Range(ctx, ipv4RangeID); err != nil {
 						t.Fatalf("delete IPv4 range out of band: %v", err)
 					}
 				},
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"text/template"
@@ -71,17 +72,18 @@ func TestAccSiteRangeRecreation(t *testing.T) {
 
 // Delete all site range resources from the API.
 func deleteSiteRangeResources() {
+	ctx := context.Background()
 	client, _ := utils.NewEnvClient()
 
 	// Pretty simple blanket statement to just remove everything.
-	sites, _ := client.GetSites()
+	sites, _ := client.GetSites(ctx)
 
 	for _, site := range sites {
 		for _, siteRange := range site.RoutableRangesV4 {
-			_ = client.DeleteSiteRange(site.ID, siteRange.ID)
+			_ = client.DeleteSiteRange(ctx, site.ID, siteRange.ID)
 		}
 		for _, siteRange := range site.RouteRangesV6 {
-			_ = client.DeleteSiteRange(site.ID, siteRange.ID)
+			_ = client.DeleteSiteRange(ctx, site.ID, siteRange.ID)
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/bowtieworks/terraform-provider-bowtie/internal/bowtie/provider"
@@ -51,11 +52,12 @@ func TestAccSiteRecreation(t *testing.T) {
 
 // Delete all site resources from the API.
 func deleteSiteResources() {
+	ctx := context.Background()
 	client, _ := utils.NewEnvClient()
 
 	// Pretty simple blanket statement to just remove everything.
-	sites, _ := client.GetSites()
+	sites, _ := client.GetSites(ctx)
 	for _, site := range sites {
-		_ = client.DeleteSite(site.ID)
+		_ = client.DeleteSite(ctx, site.ID)
 	}
 }
@@ -23,7 +23,7 @@ func init() {
 				return err
 			}
 
-			users, err := client.GetUsers()
+			users, err := client.GetUsers(ctx)
 			if err != nil {
 				return err
 			}
@@ -34,7 +34,7 @@ func init() {
 				}
 
 				if user.Role == "Owner" {
-					_, err := client.UpsertUser(user.ID, "", "", "User", false, false, false, false, false)
+					_, err := client.UpsertUser(ctx, user.ID, "", "", "User", false, false, false, false, false)
 					if err != nil {
 						fmt.Println("[Error] Failed to demote user")
 						continue
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -11,13 +12,29 @@ import (
 )
 
 func NewEnvClient() (*client.Client, error) {
-	host := os.Getenv("BOWTIE_HOST")
+	hosts := client.SplitHosts(os.Getenv("BOWTIE_HOST"))
 	username := os.Getenv("BOWTIE_USERNAME")
 	password := os.Getenv("BOWTIE_PASSWORD")
 	insecure := os.Getenv("BOWTIE_INSECURE") == "true" || os.Getenv("BOWTIE_INSECURE") == "1"
 	caBundle := os.Getenv("BOWTIE_CA_BUNDLE")
 
-	c, err := client.NewClient(host, username, password, false, true, insecure, caBundle)
+	var host string
+	var options []client.Option
+	if len(hosts) > 0 {
+		host = hosts[0]
+		options = append(options, client.WithFailoverHosts(hosts[1:]...))
+	}
+	if cert := os.Getenv("BOWTIE_CLIENT_CERT"); cert != "" {
+		options = append(options, client.WithClientCertificate(cert, os.Getenv("BOWTIE_CLIENT_KEY")))
+	}
+	if proxy := os.Getenv("BOWTIE_PROXY_URL"); proxy != "" {
+		options = append(options, client.WithProxy(proxy))
+	}
+	if token := os.Getenv("BOWTIE_API_TOKEN"); token != "" {
+		options = append(options, client.WithAPIToken(token))
+	}
+
+	c, err := client.NewClient(context.Background(), host, username, password, false, true, insecure, caBundle, options...)
 	return c, err
 }
 